@@ -0,0 +1,92 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AddonUpdateAndWait changes the plan of an add-on and waits until it is
+// provisioned on the new plan. Some plan changes, such as for databases,
+// take a while and may involve downtime; the message of the provider
+// about the change, if any, is returned along with the add-on. It fails
+// if the add-on is deprovisioned, or with a TimeoutError if it is not
+// provisioned within timeout.
+func (s *Service) AddonUpdateAndWait(appIdentity, addonIdentity string, o AddonUpdateOpts, timeout time.Duration) (*Addon, string, error) {
+	return s.AddonUpdateAndWaitWithContext(context.Background(), appIdentity, addonIdentity, o, timeout)
+}
+
+// AddonUpdateAndWaitWithContext is like AddonUpdateAndWait but its
+// requests are bound to ctx, and it stops waiting and returns the error
+// of ctx once ctx is canceled.
+func (s *Service) AddonUpdateAndWaitWithContext(ctx context.Context, appIdentity, addonIdentity string, o AddonUpdateOpts, timeout time.Duration) (*Addon, string, error) {
+	addon := new(Addon)
+	if _, err := s.do(ctx, addon, "PATCH", fmt.Sprintf("/apps/%v/addons/%v", pathEscape(appIdentity), pathEscape(addonIdentity)), o, nil); err != nil {
+		return nil, "", err
+	}
+	var msg string
+	if addon.ProvisionMessage != nil {
+		msg = *addon.ProvisionMessage
+	}
+	first := true
+	err := poll(ctx, fmt.Sprintf("add-on %s to be provisioned", addon.Name), timeout, func() (bool, error) {
+		if !first {
+			a := new(Addon)
+			if _, err := s.do(ctx, a, "GET", fmt.Sprintf("/apps/%v/addons/%v", pathEscape(appIdentity), pathEscape(addon.ID)), nil, nil); err != nil {
+				return false, err
+			}
+			addon = a
+		}
+		first = false
+		switch addon.State {
+		case "provisioned":
+			return true, nil
+		case "deprovisioned":
+			return false, fmt.Errorf("heroku: add-on %s was deprovisioned", addon.Name)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, msg, err
+	}
+	return addon, msg, nil
+}
+
+// addonSSO is the single sign-on action of an add-on.
+type addonSSO struct {
+	Action string            `json:"action"` // URL to sign on at
+	Method string            `json:"method"` // HTTP method to sign on with
+	Params map[string]string `json:"params"` // parameters to sign on with
+}
+
+// AddonSSO returns a signed URL that logs the user into the dashboard of
+// an add-on, e.g. to link to it from a browser. The URL expires after a
+// few minutes. Add-ons that do not support single sign-on fail with an
+// API error, and those that only support it through a POST form fail
+// with an error too, as there is no URL to sign on at.
+func (s *Service) AddonSSO(appIdentity, addonIdentity string) (string, error) {
+	req, err := s.NewRequest("GET", fmt.Sprintf("/apps/%v/addons/%v/sso", pathEscape(appIdentity), pathEscape(addonIdentity)), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.heroku+json; version=3.add-ons-sso")
+	var sso addonSSO
+	if _, err := s.send(&sso, req); err != nil {
+		return "", err
+	}
+	if !strings.EqualFold(sso.Method, "get") {
+		return "", fmt.Errorf("heroku: add-on %s only supports single sign-on with %s", addonIdentity, strings.ToUpper(sso.Method))
+	}
+	u, err := url.Parse(sso.Action)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	for k, v := range sso.Params {
+		q.Set(k, v)
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
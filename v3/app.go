@@ -0,0 +1,191 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// AppsByNames resolves the given app names into their full records
+// using a single request to the app filter endpoint. The result is keyed
+// by app name; names that do not match an existing app are omitted.
+func (s *Service) AppsByNames(names []string) (map[string]*App, error) {
+	apps := make(map[string]*App, len(names))
+	if len(names) == 0 {
+		return apps, nil
+	}
+	var o AppFilterListOpts
+	o.In.Name = names
+	list, err := s.AppFilterList(o)
+	if err != nil {
+		return nil, err
+	}
+	for _, app := range list {
+		apps[app.Name] = app
+	}
+	return apps, nil
+}
+
+// AppListPage lists a single page of apps. The returned cursor yields
+// the range of the following page, if any:
+//
+//	var lr *heroku.ListRange
+//	for {
+//		apps, cursor, err := s.AppListPage(lr)
+//		...
+//		if lr = cursor.Next(); lr == nil {
+//			break
+//		}
+//	}
+func (s *Service) AppListPage(lr *ListRange) ([]*App, *Cursor, error) {
+	return s.appListPage(context.Background(), lr)
+}
+
+// appListPage is like AppListPage but the request is bound to ctx.
+func (s *Service) appListPage(ctx context.Context, lr *ListRange) ([]*App, *Cursor, error) {
+	var appList []*App
+	resp, err := s.do(ctx, &appList, "GET", "/apps", nil, lr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return appList, newCursor(resp), nil
+}
+
+// AppListAll lists all apps, requesting as many pages as needed.
+func (s *Service) AppListAll() ([]*App, error) {
+	return s.appListAll(context.Background())
+}
+
+// appListAll is like AppListAll but the requests are bound to ctx.
+func (s *Service) appListAll(ctx context.Context) ([]*App, error) {
+	var all []*App
+	var lr *ListRange
+	for {
+		apps, cursor, err := s.appListPage(ctx, lr)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, apps...)
+		if lr = cursor.Next(); lr == nil {
+			return all, nil
+		}
+	}
+}
+
+// AppListByRegion lists the apps in the region with the given id or name.
+// The API has no server-side region filter, so apps are filtered from
+// AppList; lr applies to the unfiltered list, and a page may therefore
+// hold fewer apps than requested.
+func (s *Service) AppListByRegion(regionIdentity string, lr *ListRange) ([]*App, error) {
+	apps, err := s.AppList(lr)
+	if err != nil {
+		return nil, err
+	}
+	var inRegion []*App
+	for _, app := range apps {
+		if app.Region.ID == regionIdentity || app.Region.Name == regionIdentity {
+			inRegion = append(inRegion, app)
+		}
+	}
+	return inRegion, nil
+}
+
+// AppListMine lists the apps owned by or shared with the authenticated
+// user. Unlike AppList, it excludes organization apps the user can only
+// access through organization membership.
+func (s *Service) AppListMine(lr *ListRange) ([]*App, error) {
+	return s.AppListOwnedAndCollaborated(Self, lr)
+}
+
+// An ExpandedApp is an app along with its full region and stack, of
+// which App only holds the id and name.
+type ExpandedApp struct {
+	App    *App
+	Region *Region
+	Stack  *Stack
+}
+
+// AppInfoExpanded returns info for an app along with its region and
+// stack. The API has no expansion of nested resources, so they are
+// fetched with separate requests.
+func (s *Service) AppInfoExpanded(appIdentity string) (*ExpandedApp, error) {
+	app, err := s.AppInfo(appIdentity)
+	if err != nil {
+		return nil, err
+	}
+	region, err := s.RegionInfo(app.Region.ID)
+	if err != nil {
+		return nil, err
+	}
+	stack, err := s.StackInfo(app.Stack.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &ExpandedApp{App: app, Region: region, Stack: stack}, nil
+}
+
+// prepareAppCreate sets the region and stack of an app being created to
+// the defaults of s, unless they are already set, and checks them if
+// s.ValidateRegionAndStack is set. It is called by all the methods
+// creating apps, before the request is sent.
+func (s *Service) prepareAppCreate(region, stack **string) error {
+	if *region == nil && s.DefaultRegion != "" {
+		*region = String(s.DefaultRegion)
+	}
+	if *stack == nil && s.DefaultStack != "" {
+		*stack = String(s.DefaultStack)
+	}
+	if err := s.checkRegion(*region); err != nil {
+		return err
+	}
+	return s.checkStack(*stack)
+}
+
+// WebURLParsed returns the web URL of the app, which must be an HTTP or
+// HTTPS URL.
+func (a App) WebURLParsed() (*url.URL, error) {
+	u, err := url.Parse(a.WebURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("heroku: invalid web URL %q", a.WebURL)
+	}
+	return u, nil
+}
+
+// scpGitURLRe matches scp-like git URLs such as git@heroku.com:app.git.
+var scpGitURLRe = regexp.MustCompile(`^([^@/:]+)@([^/:]+):(.+)$`)
+
+// GitRemote returns the git URL of the app as a URL that can be added as
+// a git remote. Older apps have scp-like URLs (git@heroku.com:app.git),
+// which are returned as the equivalent ssh URL.
+func (a App) GitRemote() (*url.URL, error) {
+	if m := scpGitURLRe.FindStringSubmatch(a.GitURL); m != nil {
+		return &url.URL{Scheme: "ssh", User: url.User(m[1]), Host: m[2], Path: "/" + m[3]}, nil
+	}
+	u, err := url.Parse(a.GitURL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "https", "ssh":
+		return u, nil
+	}
+	return nil, fmt.Errorf("heroku: invalid git URL %q", a.GitURL)
+}
+
+// GitRemoteWithToken is like GitRemote but, for HTTPS remotes, includes
+// token as the password, so that git can push without a credential
+// helper. The resulting URL contains a secret and must not be logged.
+func (a App) GitRemoteWithToken(token string) (*url.URL, error) {
+	u, err := a.GitRemote()
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "https" {
+		u.User = url.UserPassword("", token)
+	}
+	return u, nil
+}
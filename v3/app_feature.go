@@ -0,0 +1,46 @@
+package heroku
+
+// AppFeatureEnable enables a feature of the app. As enabling a feature
+// may enable or disable others, the features whose state changed as a
+// side effect are returned along with the updated feature.
+func (s *Service) AppFeatureEnable(appIdentity, appFeatureIdentity string) (*AppFeature, []*AppFeature, error) {
+	before, err := s.AppFeatureList(appIdentity, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	feature, err := s.AppFeatureUpdate(appIdentity, appFeatureIdentity, AppFeatureUpdateOpts{Enabled: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	after, err := s.AppFeatureList(appIdentity, nil)
+	if err != nil {
+		return feature, nil, err
+	}
+
+	enabled := make(map[string]bool, len(before))
+	for _, f := range before {
+		enabled[f.ID] = f.Enabled
+	}
+	var changed []*AppFeature
+	for _, f := range after {
+		if was, ok := enabled[f.ID]; f.ID != feature.ID && (!ok || was != f.Enabled) {
+			changed = append(changed, f)
+		}
+	}
+	return feature, changed, nil
+}
+
+// AppFeatureListEnabled lists the features that are enabled on the app.
+func (s *Service) AppFeatureListEnabled(appIdentity string) ([]*AppFeature, error) {
+	features, err := s.AppFeatureList(appIdentity, nil)
+	if err != nil {
+		return nil, err
+	}
+	var enabled []*AppFeature
+	for _, f := range features {
+		if f.Enabled {
+			enabled = append(enabled, f)
+		}
+	}
+	return enabled, nil
+}
@@ -0,0 +1,92 @@
+package heroku
+
+import "fmt"
+
+// AppProvisionSpec describes a fully configured app to be created by
+// AppProvision.
+type AppProvisionSpec struct {
+	App        AppCreateOpts     // options used to create the app
+	ConfigVars map[string]string // config vars to set on the app
+	Buildpacks []string          // buildpack names or URLs, in execution order
+	Addons     []string          // add-on plans to provision, e.g. "heroku-postgresql:hobby-dev"
+
+	// OnStep, if set, is called before each step is performed.
+	OnStep func(step string)
+}
+
+// ProvisionError is returned by AppProvision when one of its steps
+// fails.
+type ProvisionError struct {
+	App         string // name of the app being provisioned, if it was created
+	Step        string // step that failed
+	Err         error  // error returned by the failed step
+	RollbackErr error  // error deleting the app after the failure, if any
+}
+
+func (e *ProvisionError) Error() string {
+	msg := fmt.Sprintf("heroku: provisioning app failed at step %q: %v", e.Step, e.Err)
+	if e.RollbackErr != nil {
+		msg += fmt.Sprintf(" (rollback of app %s failed: %v)", e.App, e.RollbackErr)
+	}
+	return msg
+}
+
+// AppProvision creates an app, then sets its config vars, buildpacks and
+// add-ons in sequence. If any step after the app creation fails, the app
+// is deleted again and a *ProvisionError describing the failed step is
+// returned.
+func (s *Service) AppProvision(spec AppProvisionSpec) (*App, error) {
+	step := func(name string) string {
+		if spec.OnStep != nil {
+			spec.OnStep(name)
+		}
+		return name
+	}
+
+	name := step("create app")
+	app, err := s.AppCreate(spec.App)
+	if err != nil {
+		return nil, &ProvisionError{Step: name, Err: err}
+	}
+	fail := func(name string, err error) (*App, error) {
+		return nil, &ProvisionError{
+			App:         app.Name,
+			Step:        name,
+			Err:         err,
+			RollbackErr: s.AppDelete(app.ID),
+		}
+	}
+
+	if len(spec.ConfigVars) > 0 {
+		name := step("set config vars")
+		vars := make(map[string]*string, len(spec.ConfigVars))
+		for k, v := range spec.ConfigVars {
+			vars[k] = String(v)
+		}
+		if _, err := s.ConfigVarUpdate(app.ID, vars); err != nil {
+			return fail(name, err)
+		}
+	}
+
+	if len(spec.Buildpacks) > 0 {
+		name := step("set buildpacks")
+		var o BuildpackInstallationUpdateOpts
+		for _, bp := range spec.Buildpacks {
+			o.Updates = append(o.Updates, struct {
+				Buildpack string `json:"buildpack"`
+			}{bp})
+		}
+		if _, err := s.BuildpackInstallationUpdate(app.ID, o); err != nil {
+			return fail(name, err)
+		}
+	}
+
+	for _, plan := range spec.Addons {
+		name := step("add add-on " + plan)
+		if _, err := s.AddonCreate(app.ID, AddonCreateOpts{Plan: plan}); err != nil {
+			return fail(name, err)
+		}
+	}
+
+	return app, nil
+}
@@ -0,0 +1,87 @@
+package heroku
+
+import (
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestAppProvisionRollback(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /apps":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"01","name":"example"}`))
+		case "PUT /apps/01/buildpack-installations":
+			w.Write([]byte(`[{"ordinal":0,"buildpack":{"name":"heroku/go"}}]`))
+		case "POST /apps/01/addons":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"id":"invalid_params","message":"Couldn't find that plan."}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	s.OnConfigVarChange = func(ConfigVarAudit) {}
+
+	var steps []string
+	app, err := s.AppProvision(AppProvisionSpec{
+		ConfigVars: map[string]string{"FOO": "bar"},
+		Buildpacks: []string{"heroku/go"},
+		Addons:     []string{"heroku-postgresql:missing"},
+		OnStep:     func(step string) { steps = append(steps, step) },
+	})
+	if app != nil {
+		t.Errorf("app = %v, want nil", app)
+	}
+	e, ok := err.(*ProvisionError)
+	if !ok {
+		t.Fatalf("err = %v, want a *ProvisionError", err)
+	}
+	if e.Step != "add add-on heroku-postgresql:missing" || e.App != "example" || e.RollbackErr != nil {
+		t.Errorf("ProvisionError = %+v", e)
+	}
+	if _, ok := e.Err.(Error); !ok {
+		t.Errorf("Err = %v, want the API error", e.Err)
+	}
+
+	wantSteps := []string{"create app", "set config vars", "set buildpacks", "add add-on heroku-postgresql:missing"}
+	if !reflect.DeepEqual(steps, wantSteps) {
+		t.Errorf("steps = %q, want %q", steps, wantSteps)
+	}
+	wantRequests := []string{
+		"POST /apps",
+		"PATCH /apps/01/config-vars",
+		"PUT /apps/01/buildpack-installations",
+		"POST /apps/01/addons",
+		"DELETE /apps/01",
+	}
+	if !reflect.DeepEqual(requests, wantRequests) {
+		t.Errorf("requests = %q, want %q", requests, wantRequests)
+	}
+}
+
+func TestAppProvisionCreateFails(t *testing.T) {
+	var deleted bool
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			deleted = true
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"id":"invalid_params","message":"Name is already taken."}`))
+	}))
+	_, err := s.AppProvision(AppProvisionSpec{App: AppCreateOpts{Name: String("taken")}})
+	if e, ok := err.(*ProvisionError); !ok || e.Step != "create app" || e.App != "" {
+		t.Errorf("err = %#v, want a ProvisionError for the create app step", err)
+	}
+	if deleted {
+		t.Error("app deleted although it was not created")
+	}
+}
@@ -0,0 +1,35 @@
+package heroku
+
+// AppTransferListIncoming lists the app transfers for which the current
+// account is the recipient, e.g. those awaiting its approval.
+func (s *Service) AppTransferListIncoming(lr *ListRange) ([]*AppTransfer, error) {
+	return s.appTransferListFiltered(lr, func(t *AppTransfer, accountID string) bool {
+		return t.Recipient.ID == accountID
+	})
+}
+
+// AppTransferListOutgoing lists the app transfers for which the current
+// account is the owner of the app.
+func (s *Service) AppTransferListOutgoing(lr *ListRange) ([]*AppTransfer, error) {
+	return s.appTransferListFiltered(lr, func(t *AppTransfer, accountID string) bool {
+		return t.Owner.ID == accountID
+	})
+}
+
+func (s *Service) appTransferListFiltered(lr *ListRange, keep func(*AppTransfer, string) bool) ([]*AppTransfer, error) {
+	account, err := s.AccountInfo()
+	if err != nil {
+		return nil, err
+	}
+	transfers, err := s.AppTransferList(lr)
+	if err != nil {
+		return nil, err
+	}
+	var filtered []*AppTransfer
+	for _, t := range transfers {
+		if keep(t, account.ID) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered, nil
+}
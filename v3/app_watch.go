@@ -0,0 +1,91 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Types of AppEvent.
+const (
+	AppAdded   = "added"
+	AppUpdated = "updated"
+	AppDeleted = "deleted"
+)
+
+// An AppEvent is a change to the apps of the account seen by AppWatch.
+type AppEvent struct {
+	Type string // AppAdded, AppUpdated or AppDeleted, or "" if Err is set
+	App  *App   // app after the change, or before it was deleted
+	Err  error  // error listing apps, after which polling goes on
+}
+
+// AppWatch polls the apps of the account every interval and sends an
+// event on the returned channel for each app added, updated or deleted
+// since the previous poll. Apps are compared by ID and UpdatedAt. All
+// apps are sent as added after the first poll, so that the receiver
+// starts from the full list. Errors are sent as events and polling goes
+// on. The channel is closed once ctx is canceled; events must be
+// received for polling to continue. If interval is not positive, a
+// single event with an error is sent and the channel is closed.
+func (s *Service) AppWatch(ctx context.Context, interval time.Duration) <-chan AppEvent {
+	if interval <= 0 {
+		events := make(chan AppEvent, 1)
+		events <- AppEvent{Err: fmt.Errorf("heroku: invalid AppWatch interval %v", interval)}
+		close(events)
+		return events
+	}
+	events := make(chan AppEvent)
+	go func() {
+		defer close(events)
+		send := func(e AppEvent) bool {
+			select {
+			case events <- e:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		seen := make(map[string]*App)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			apps, err := s.appListAll(ctx)
+			if err != nil {
+				if !send(AppEvent{Err: err}) {
+					return
+				}
+			} else {
+				current := make(map[string]*App, len(apps))
+				for _, app := range apps {
+					current[app.ID] = app
+					prev, ok := seen[app.ID]
+					switch {
+					case !ok:
+						if !send(AppEvent{Type: AppAdded, App: app}) {
+							return
+						}
+					case !prev.UpdatedAt.Equal(app.UpdatedAt):
+						if !send(AppEvent{Type: AppUpdated, App: app}) {
+							return
+						}
+					}
+				}
+				for id, app := range seen {
+					if _, ok := current[id]; !ok {
+						if !send(AppEvent{Type: AppDeleted, App: app}) {
+							return
+						}
+					}
+				}
+				seen = current
+			}
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
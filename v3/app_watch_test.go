@@ -0,0 +1,72 @@
+package heroku
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAppWatch(t *testing.T) {
+	var polls int32
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&polls, 1) == 1 {
+			w.Write([]byte(`[
+				{"id":"a","name":"alpha","updated_at":"2015-01-01T00:00:00Z"},
+				{"id":"b","name":"bravo","updated_at":"2015-01-01T00:00:00Z"}
+			]`))
+			return
+		}
+		w.Write([]byte(`[
+			{"id":"a","name":"alpha","updated_at":"2015-01-02T00:00:00Z"},
+			{"id":"c","name":"charlie","updated_at":"2015-01-01T00:00:00Z"}
+		]`))
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := s.AppWatch(ctx, time.Millisecond)
+	want := []struct{ typ, name string }{
+		{AppAdded, "alpha"},
+		{AppAdded, "bravo"},
+		{AppUpdated, "alpha"},
+		{AppAdded, "charlie"},
+		{AppDeleted, "bravo"},
+	}
+	for _, w := range want {
+		select {
+		case e := <-events:
+			if e.Err != nil {
+				t.Fatal(e.Err)
+			}
+			if e.Type != w.typ || e.App.Name != w.name {
+				t.Errorf("event = %s %s, want %s %s", e.Type, e.App.Name, w.typ, w.name)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no event, want %s %s", w.typ, w.name)
+		}
+	}
+
+	// Unchanged apps send no events.
+	select {
+	case e := <-events:
+		t.Errorf("unexpected event %s %v", e.Type, e.App)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestAppWatchInvalidInterval(t *testing.T) {
+	events := NewService(nil).AppWatch(context.Background(), 0)
+	if e, ok := <-events; !ok || e.Err == nil {
+		t.Errorf("first event = %+v, want an error", e)
+	}
+	if _, ok := <-events; ok {
+		t.Error("channel not closed after the error")
+	}
+}
@@ -0,0 +1,40 @@
+package heroku
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// PollURL returns the URL at which to follow an operation that the API
+// accepted with 202 Accepted but completes asynchronously, such as some
+// add-on provisionings, as given by the Location header of resp. It
+// returns "" for responses with another status code or no Location.
+//
+// Such responses are successful: their body, usually the resource in a
+// pending state, is decoded like any other. Use DoResponse to check for
+// them:
+//
+//	resp, err := s.DoResponse(&addon, "POST", "/apps/example/addons", o, nil)
+//	if err == nil {
+//		if u := heroku.PollURL(resp); u != "" {
+//			...
+//		}
+//	}
+func PollURL(resp *http.Response) string {
+	if resp.StatusCode != http.StatusAccepted {
+		return ""
+	}
+	loc := resp.Header.Get("Location")
+	if loc == "" {
+		return ""
+	}
+	base, _ := url.Parse(DefaultAPIURL)
+	if resp.Request != nil {
+		base = resp.Request.URL
+	}
+	u, err := base.Parse(loc)
+	if err != nil {
+		return loc
+	}
+	return u.String()
+}
@@ -0,0 +1,77 @@
+package heroku
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A ConfigVarAudit describes a change of config vars about to be sent.
+// It holds the names of the changed config vars but never their values.
+type ConfigVarAudit struct {
+	App    string   // id or name of the app, as given in the request
+	Keys   []string // names of the config vars set or unset, sorted
+	Actor  string   // who makes the change, as given to WithAudit
+	Reason string   // why the change is made, as given to WithAudit
+}
+
+type auditContextKey struct{}
+
+type audit struct {
+	actor, reason string
+}
+
+// WithAudit returns a copy of ctx that attributes config var changes
+// sent with it, e.g. using DoWithContext, to actor for reason. The API
+// does not record them: they are passed to Service.OnConfigVarChange.
+func WithAudit(ctx context.Context, actor, reason string) context.Context {
+	return context.WithValue(ctx, auditContextKey{}, audit{actor, reason})
+}
+
+// ConfigVarUpdateAudited is like ConfigVarUpdate but attributes the
+// change to actor for reason; see WithAudit.
+func (s *Service) ConfigVarUpdateAudited(appIdentity string, o map[string]*string, actor, reason string) (ConfigVar, error) {
+	var configVar ConfigVar
+	ctx := WithAudit(context.Background(), actor, reason)
+	return configVar, s.DoWithContext(ctx, &configVar, "PATCH", fmt.Sprintf("/apps/%v/config-vars", pathEscape(appIdentity)), o, nil)
+}
+
+// configVarPathRe matches the path of config vars and captures the app.
+var configVarPathRe = regexp.MustCompile(`^/apps/([^/]+)/config-vars$`)
+
+// auditConfigVars reports req to s.OnConfigVarChange, or to s.Logger if
+// no hook is set, if it changes config vars.
+func (s *Service) auditConfigVars(req *http.Request) {
+	if req.Method != "PATCH" || (s.OnConfigVarChange == nil && s.Logger == nil) {
+		return
+	}
+	m := configVarPathRe.FindStringSubmatch(req.URL.Path)
+	if m == nil {
+		return
+	}
+	a := ConfigVarAudit{App: m[1]}
+	if v, ok := req.Context().Value(auditContextKey{}).(audit); ok {
+		a.Actor, a.Reason = v.actor, v.reason
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			var updates map[string]json.RawMessage
+			if json.NewDecoder(body).Decode(&updates) == nil {
+				for k := range updates {
+					a.Keys = append(a.Keys, k)
+				}
+				sort.Strings(a.Keys)
+			}
+			body.Close()
+		}
+	}
+	if s.OnConfigVarChange != nil {
+		s.OnConfigVarChange(a)
+		return
+	}
+	s.Logger.Printf("heroku: changing config vars %s of app %s by %q: %q", strings.Join(a.Keys, ", "), a.App, a.Actor, a.Reason)
+}
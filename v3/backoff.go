@@ -0,0 +1,45 @@
+package heroku
+
+import (
+	"math/rand"
+	"time"
+)
+
+// A Backoff computes how long to wait before retrying an operation. The
+// package uses it when polling and retrying, and it is exported so that
+// callers writing their own loops behave the same way.
+type Backoff interface {
+	// Next returns the delay to wait before the given attempt, starting
+	// at 1 for the first retry.
+	Next(attempt int) time.Duration
+}
+
+// ExponentialBackoff doubles the delay on each attempt, starting from
+// Base and capped at Max. Half of each delay is randomized so that
+// concurrent clients do not retry in lockstep.
+type ExponentialBackoff struct {
+	Base time.Duration // delay before the first retry, 500ms if zero
+	Max  time.Duration // maximum delay, 30s if zero
+}
+
+// DefaultBackoff is the Backoff used by the package when none is given.
+var DefaultBackoff Backoff = ExponentialBackoff{}
+
+func (b ExponentialBackoff) Next(attempt int) time.Duration {
+	base, max := b.Base, b.Max
+	if base <= 0 {
+		base = 500 * time.Millisecond
+	}
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+	d := base
+	for i := 1; i < attempt && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
@@ -0,0 +1,62 @@
+package heroku
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultBuildpackRegistryURL is the base URL of the Heroku buildpack
+// registry.
+const DefaultBuildpackRegistryURL = "https://buildpack-registry.heroku.com"
+
+// A buildpack is published in the buildpack registry under a namespace
+// and name, such as heroku/nodejs.
+type Buildpack struct {
+	BlobURL     string    `json:"blob_url"`     // URL of the buildpack archive
+	Category    string    `json:"category"`     // category of the buildpack, e.g. languages
+	CreatedAt   time.Time `json:"created_at"`   // when buildpack was published
+	Description string    `json:"description"`  // description of the buildpack
+	HomepageURL string    `json:"homepage_url"` // URL of the buildpack homepage
+	ID          string    `json:"id"`           // unique identifier of the buildpack
+	Name        string    `json:"name"`         // name of the buildpack within its namespace
+	Namespace   string    `json:"namespace"`    // namespace of the buildpack, e.g. heroku
+	UpdatedAt   time.Time `json:"updated_at"`   // when buildpack was updated
+}
+
+// FullName returns the namespaced name of the buildpack, e.g.
+// heroku/nodejs, or its URL for buildpacks outside the registry.
+func (b Buildpack) FullName() string {
+	if b.Namespace == "" {
+		return b.BlobURL
+	}
+	return b.Namespace + "/" + b.Name
+}
+
+// Info for a buildpack of the registry, given its id or namespaced name.
+func (s *Service) BuildpackInfo(buildpackIdentity string) (*Buildpack, error) {
+	var buildpack Buildpack
+	return &buildpack, s.Get(&buildpack, fmt.Sprintf("%s/buildpacks/%s", DefaultBuildpackRegistryURL, url.PathEscape(buildpackIdentity)), nil)
+}
+
+var (
+	buildpackNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*/[a-z0-9][a-z0-9_-]*$`)
+	uuidRe          = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+// BuildpackResolve resolves a buildpack reference as stored in
+// buildpack installations: a registry id, a namespaced name such as
+// heroku/nodejs, a urn:buildpack: name or a URL. Registry references are
+// looked up in the registry; URLs are returned as is, in BlobURL.
+func (s *Service) BuildpackResolve(ref string) (*Buildpack, error) {
+	ref = strings.TrimPrefix(ref, "urn:buildpack:")
+	if strings.Contains(ref, "://") {
+		return &Buildpack{BlobURL: ref}, nil
+	}
+	if !uuidRe.MatchString(ref) && !buildpackNameRe.MatchString(ref) {
+		return nil, fmt.Errorf("heroku: invalid buildpack reference %q", ref)
+	}
+	return s.BuildpackInfo(ref)
+}
@@ -0,0 +1,50 @@
+package heroku
+
+import (
+	"fmt"
+	"sync"
+)
+
+// identity is the id and name of a resource, such as a region.
+type identity struct {
+	ID   string
+	Name string
+}
+
+// identityCache holds a list of identities fetched once, such as the
+// regions or the dyno sizes offered by the API.
+type identityCache struct {
+	mu      sync.Mutex
+	fetched bool
+	ids     []identity
+}
+
+// lookup returns the name of the identity whose id or name is v, calling
+// fetch to fill the cache if needed, or an error naming what kind of
+// resource is unknown.
+//
+// The list is fetched without holding the lock, so that other callers
+// are not blocked by a slow request; concurrent first calls may fetch it
+// more than once.
+func (c *identityCache) lookup(kind, v string, fetch func() ([]identity, error)) (string, error) {
+	c.mu.Lock()
+	ids, fetched := c.ids, c.fetched
+	c.mu.Unlock()
+	if !fetched {
+		var err error
+		if ids, err = fetch(); err != nil {
+			return "", err
+		}
+		c.mu.Lock()
+		c.ids, c.fetched = ids, true
+		c.mu.Unlock()
+	}
+	var names []string
+	for _, id := range ids {
+		if id.ID == v || id.Name == v {
+			return id.Name, nil
+		}
+		names = append(names, id.Name)
+	}
+	return "", fmt.Errorf("heroku: unknown %s %q, must be one of %v", kind, v, names)
+}
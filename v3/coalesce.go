@@ -0,0 +1,120 @@
+package heroku
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// flightCall is a request in flight shared by several callers.
+type flightCall struct {
+	done chan struct{} // closed once resp, body and err are set
+	resp *http.Response
+	body []byte
+	err  error
+}
+
+// flightGroup deduplicates concurrent requests by key.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+// do calls fn once for all concurrent callers using the same key and
+// returns its results to each of them. fn runs on its own, so that it is
+// not interrupted when a caller gives up: each caller waits for the
+// results until its ctx is done.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (*http.Response, []byte, error)) (*http.Response, []byte, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	c, ok := g.calls[key]
+	if !ok {
+		c = &flightCall{done: make(chan struct{})}
+		g.calls[key] = c
+		go func() {
+			c.resp, c.body, c.err = fn()
+			g.mu.Lock()
+			delete(g.calls, key)
+			g.mu.Unlock()
+			close(c.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.resp, c.body, c.err
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+}
+
+// callerHeaders are request headers specific to a caller, such as those
+// set from the context with WithRequestID or WithTwoFactorCode. Requests
+// carrying any of them are never coalesced.
+var callerHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Heroku-Two-Factor-Code",
+	"Idempotency-Key",
+	"Request-Id",
+}
+
+// coalescable reports whether req may share its round trip with other
+// requests.
+func coalescable(req *http.Request) bool {
+	if req.Method != "GET" {
+		return false
+	}
+	for _, h := range callerHeaders {
+		if req.Header.Get(h) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultCoalesceTimeout limits shared round trips if s.Timeout is not
+// set.
+const defaultCoalesceTimeout = time.Minute
+
+// sendCoalesced is like send but shares the round trip with identical
+// requests in flight, which must be coalescable. Requests are identical
+// if they have the same URL and the same Accept and Range headers. Each
+// caller decodes its own copy of the body and gets its own headers.
+//
+// The shared round trip is detached from the context of the request
+// starting it, so that other callers are not affected when that request
+// is canceled, and is limited by s.Timeout or defaultCoalesceTimeout
+// instead. Each caller still stops waiting once its own context is done.
+func (s *Service) sendCoalesced(v interface{}, req *http.Request) (*http.Response, error) {
+	key := req.Method + " " + req.URL.String() + "\n" + req.Header.Get("Accept") + "\n" + req.Header.Get("Range")
+	shared, body, err := s.flights.do(req.Context(), key, func() (*http.Response, []byte, error) {
+		timeout := s.Timeout
+		if timeout <= 0 {
+			timeout = defaultCoalesceTimeout
+		}
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(req.Context()), timeout)
+		defer cancel()
+		resp, err := s.roundTrip(req.WithContext(ctx))
+		if err != nil {
+			return nil, nil, err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		return resp, body, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := *shared
+	resp.Header = shared.Header.Clone()
+	resp.Trailer = shared.Trailer.Clone()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return &resp, decodeResponse(v, &resp)
+}
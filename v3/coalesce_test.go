@@ -0,0 +1,107 @@
+package heroku
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCoalesceCanceledCaller(t *testing.T) {
+	var requests int32
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			close(arrived)
+		}
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"example"}`))
+	}))
+	s.Coalesce = true
+
+	// The first caller starts the shared round trip, then gives up.
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		first <- s.DoWithContext(ctx, new(App), "GET", "/apps/example", nil, nil)
+	}()
+	<-arrived
+
+	// The other callers join it before it completes.
+	const waiters = 3
+	var wg sync.WaitGroup
+	apps := make([]App, waiters)
+	errs := make([]error, waiters)
+	for i := range apps {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.DoWithContext(context.Background(), &apps[i], "GET", "/apps/example", nil, nil)
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-first:
+		if err != context.Canceled {
+			t.Errorf("canceled caller: err = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("canceled caller still waiting for the shared round trip")
+	}
+
+	close(release)
+	wg.Wait()
+	for i := range apps {
+		if errs[i] != nil {
+			t.Errorf("waiter %d: %v", i, errs[i])
+		} else if apps[i].Name != "example" {
+			t.Errorf("waiter %d: app = %q, want example", i, apps[i].Name)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n > 1+waiters {
+		t.Errorf("%d requests sent", n)
+	}
+}
+
+func TestCoalesceSharesRoundTrip(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"example"}`))
+	}))
+	s.Coalesce = true
+
+	const callers = 4
+	var wg sync.WaitGroup
+	errs := make([]error, callers)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = s.AppInfo("example")
+		}(i)
+	}
+	for atomic.LoadInt32(&requests) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("caller %d: %v", i, err)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n >= callers {
+		t.Errorf("%d requests sent for %d identical calls, want them shared", n, callers)
+	}
+}
@@ -0,0 +1,72 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CollaboratorReconcile makes the collaborators of the app match
+// desiredEmails: missing collaborators are added and the others
+// removed. The owner of the app is never removed. Emails are compared
+// case-insensitively, against all pages of the collaborator list.
+func (s *Service) CollaboratorReconcile(appIdentity string, desiredEmails []string) error {
+	app, err := s.AppInfo(appIdentity)
+	if err != nil {
+		return err
+	}
+	var collaborators []*Collaborator
+	var lr *ListRange
+	for {
+		var page []*Collaborator
+		resp, err := s.do(context.Background(), &page, "GET", fmt.Sprintf("/apps/%v/collaborators", pathEscape(appIdentity)), nil, lr)
+		if err != nil {
+			return err
+		}
+		collaborators = append(collaborators, page...)
+		if lr = newCursor(resp).Next(); lr == nil {
+			break
+		}
+	}
+
+	desired := make(map[string]bool, len(desiredEmails))
+	for _, email := range desiredEmails {
+		desired[strings.ToLower(email)] = true
+	}
+	owner := strings.ToLower(app.Owner.Email)
+
+	existing := make(map[string]bool, len(collaborators))
+	for _, c := range collaborators {
+		email := strings.ToLower(c.User.Email)
+		existing[email] = true
+		if desired[email] || email == owner {
+			continue
+		}
+		if err := s.CollaboratorDelete(appIdentity, c.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, email := range desiredEmails {
+		if existing[strings.ToLower(email)] || strings.ToLower(email) == owner {
+			continue
+		}
+		if _, err := s.CollaboratorCreate(appIdentity, CollaboratorCreateOpts{User: email}); err != nil {
+			return err
+		}
+		existing[strings.ToLower(email)] = true
+	}
+	return nil
+}
+
+// CollaboratorCreateWithPermissions adds user as collaborator of an
+// organization app with the given permissions, e.g. "view" and "deploy",
+// through the organization app collaborators endpoint. The returned
+// collaborator carries the role of user in the organization. Personal
+// apps have no permissions: use CollaboratorCreate for them.
+func (s *Service) CollaboratorCreateWithPermissions(appIdentity, user string, permissions ...string) (*OrganizationAppCollaborator, error) {
+	return s.OrganizationAppCollaboratorCreate(appIdentity, OrganizationAppCollaboratorCreateOpts{
+		Permissions: permissions,
+		User:        user,
+	})
+}
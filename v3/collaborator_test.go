@@ -0,0 +1,32 @@
+package heroku
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCollaboratorReconcilePages(t *testing.T) {
+	h := &pagedHandler{path: "/apps/example/collaborators", pages: []string{
+		`[{"id":"01","user":{"email":"owner@example.com"}},{"id":"02","user":{"email":"Keep@example.com"}}]`,
+		`[{"id":"03","user":{"email":"page2@example.com"}},{"id":"04","user":{"email":"extra@example.com"}}]`,
+	}}
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/apps/example" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"owner":{"email":"owner@example.com"}}`))
+			return
+		}
+		h.ServeHTTP(w, r)
+	}))
+	if err := s.CollaboratorReconcile("example", []string{"keep@example.com", "PAGE2@example.com", "new@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"DELETE /apps/example/collaborators/04",
+		"POST /apps/example/collaborators",
+	}
+	if got := h.requests(); !reflect.DeepEqual(got, want) {
+		t.Errorf("requests = %q, want %q", got, want)
+	}
+}
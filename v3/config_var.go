@@ -0,0 +1,206 @@
+package heroku
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SortedKeys returns the config var names in lexical order, which is
+// useful for producing stable output when displaying or diffing config.
+//
+// Marshaling a ConfigVar with encoding/json already emits its keys in
+// sorted order, so the JSON form of a ConfigVar is deterministic.
+func (c ConfigVar) SortedKeys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Env returns the config vars as KEY=VALUE pairs sorted by name, as
+// expected by exec.Cmd.Env. Values are used as is: environment variables
+// need no quoting, even when holding spaces or newlines.
+func (c ConfigVar) Env() []string {
+	env := make([]string, 0, len(c))
+	for _, k := range c.SortedKeys() {
+		env = append(env, k+"="+c[k])
+	}
+	return env
+}
+
+// Merged returns environ, a list of KEY=VALUE pairs such as the one
+// returned by os.Environ, with the config vars added to it. Config vars
+// take precedence over variables of environ with the same name, which
+// are replaced in place; the others are appended sorted by name.
+func (c ConfigVar) Merged(environ []string) []string {
+	env := make([]string, 0, len(environ)+len(c))
+	seen := make(map[string]bool, len(c))
+	for _, kv := range environ {
+		k, _, _ := strings.Cut(kv, "=")
+		if v, ok := c[k]; ok {
+			if !seen[k] {
+				env = append(env, k+"="+v)
+				seen[k] = true
+			}
+			continue
+		}
+		env = append(env, kv)
+	}
+	for _, k := range c.SortedKeys() {
+		if !seen[k] {
+			env = append(env, k+"="+c[k])
+		}
+	}
+	return env
+}
+
+// ConfigVarInfoWithETag is like ConfigVarInfo but also returns the ETag
+// of the config vars, to be passed to ConfigVarUpdateIfMatch.
+func (s *Service) ConfigVarInfoWithETag(appIdentity string) (ConfigVar, string, error) {
+	var configVar ConfigVar
+	resp, err := s.DoResponse(&configVar, "GET", fmt.Sprintf("/apps/%v/config-vars", pathEscape(appIdentity)), nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	return configVar, resp.Header.Get("ETag"), nil
+}
+
+// ConfigVarUpdateIfMatch is like ConfigVarUpdate but only applies the
+// updates if the config vars have not changed since etag was obtained
+// from ConfigVarInfoWithETag. Otherwise an error for which IsConflict
+// reports true is returned.
+func (s *Service) ConfigVarUpdateIfMatch(appIdentity string, updates map[string]*string, etag string) (ConfigVar, error) {
+	req, err := s.NewRequest("PATCH", fmt.Sprintf("/apps/%v/config-vars", pathEscape(appIdentity)), updates)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("If-Match", etag)
+	var configVar ConfigVar
+	_, err = s.send(&configVar, req)
+	return configVar, err
+}
+
+// ConfigVarDeleteAll unsets every config var of the app in a single
+// update.
+func (s *Service) ConfigVarDeleteAll(appIdentity string) error {
+	current, err := s.ConfigVarInfo(appIdentity)
+	if err != nil {
+		return err
+	}
+	if len(current) == 0 {
+		return nil
+	}
+	updates := make(map[string]*string, len(current))
+	for k := range current {
+		updates[k] = nil
+	}
+	_, err = s.ConfigVarUpdate(appIdentity, updates)
+	return err
+}
+
+// ConfigVarReplace makes the config vars of the app exactly match
+// desired: vars that differ are set and vars missing from desired are
+// unset, in a single update. No request is made if nothing changes.
+func (s *Service) ConfigVarReplace(appIdentity string, desired map[string]string) (ConfigVar, error) {
+	current, err := s.ConfigVarInfo(appIdentity)
+	if err != nil {
+		return nil, err
+	}
+	updates := make(map[string]*string)
+	for k, v := range desired {
+		if cur, ok := current[k]; !ok || cur != v {
+			updates[k] = String(v)
+		}
+	}
+	for k := range current {
+		if _, ok := desired[k]; !ok {
+			updates[k] = nil
+		}
+	}
+	if len(updates) == 0 {
+		return current, nil
+	}
+	return s.ConfigVarUpdate(appIdentity, updates)
+}
+
+// configVarPatchAttempts is the number of times ConfigVarSetJSONPath
+// reads and updates a config var when it is modified concurrently.
+const configVarPatchAttempts = 3
+
+// ConfigVarSetJSONPath sets the element at jsonPath of the JSON document
+// held by the config var key to value, leaving the rest of the document
+// untouched. jsonPath is a dot-separated list of object keys, optionally
+// prefixed with "$.", e.g. "$.db.pool"; a segment made of digits indexes
+// an existing array. Missing objects along the path are created, as is
+// the config var itself. An empty path or "$" replaces the whole value.
+//
+// The update is made with an If-Match precondition on the config vars
+// read, and retried if they were modified concurrently. An error for
+// which IsConflict reports true is returned if they keep changing.
+func (s *Service) ConfigVarSetJSONPath(appIdentity, key, jsonPath string, value interface{}) (ConfigVar, error) {
+	path := splitJSONPath(jsonPath)
+	for attempt := 1; ; attempt++ {
+		current, etag, err := s.ConfigVarInfoWithETag(appIdentity)
+		if err != nil {
+			return nil, err
+		}
+		var doc interface{}
+		if raw, ok := current[key]; ok && raw != "" {
+			if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+				return nil, fmt.Errorf("heroku: config var %s is not JSON: %v", key, err)
+			}
+		}
+		if doc, err = setJSONPath(doc, path, value); err != nil {
+			return nil, fmt.Errorf("heroku: config var %s: %v", key, err)
+		}
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := s.ConfigVarUpdateIfMatch(appIdentity, map[string]*string{key: String(string(b))}, etag)
+		if err == nil || attempt == configVarPatchAttempts || !IsConflict(err) {
+			return updated, err
+		}
+	}
+}
+
+// splitJSONPath splits a path such as "$.db.pool" into its segments.
+func splitJSONPath(jsonPath string) []string {
+	p := strings.TrimPrefix(strings.TrimPrefix(jsonPath, "$"), ".")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, ".")
+}
+
+// setJSONPath returns doc, a decoded JSON document, with the element at
+// path set to value.
+func setJSONPath(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	switch node := doc.(type) {
+	case nil:
+		v, err := setJSONPath(nil, path[1:], value)
+		return map[string]interface{}{path[0]: v}, err
+	case map[string]interface{}:
+		v, err := setJSONPath(node[path[0]], path[1:], value)
+		node[path[0]] = v
+		return node, err
+	case []interface{}:
+		i, err := strconv.Atoi(path[0])
+		if err != nil || i < 0 || i >= len(node) {
+			return nil, fmt.Errorf("invalid array index %q", path[0])
+		}
+		v, err := setJSONPath(node[i], path[1:], value)
+		node[i] = v
+		return node, err
+	default:
+		return nil, fmt.Errorf("cannot set %q of non-object value", path[0])
+	}
+}
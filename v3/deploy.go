@@ -0,0 +1,257 @@
+package heroku
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// buildTimeout is how long Deploy waits for a build to finish, unless
+// the context of DeployWithContext has a deadline.
+const buildTimeout = 30 * time.Minute
+
+// Deploy builds the source code in dir and releases it on the app. The
+// directory is packed into a gzipped tarball, uploaded as a source and
+// built; the release created by the build is returned.
+//
+// The .git directory and files matching the patterns of the .slugignore
+// and .gitignore files at the root of dir are left out of the tarball.
+// Patterns follow the .gitignore syntax, including ** to match any
+// number of directories, except that negated patterns (!pattern) are not
+// supported. The tarball is written to a temporary
+// file, so that large directories are never held in memory.
+func (s *Service) Deploy(appIdentity, dir string) (*Release, error) {
+	return s.DeployWithContext(context.Background(), appIdentity, dir, nil)
+}
+
+// DeployWithContext is like Deploy but its requests are bound to ctx, so
+// that it is aborted once ctx is canceled.
+// If ctx has a deadline, the build is waited for until then instead of
+// for 30 minutes. If output is not nil, the output of the build is
+// streamed to it; if streaming fails, the error is returned without
+// waiting for the build, which goes on and can be checked with
+// BuildInfo.
+func (s *Service) DeployWithContext(ctx context.Context, appIdentity, dir string, output io.Writer) (*Release, error) {
+	f, err := os.CreateTemp("", "heroku-source-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err := tarDir(f, dir); err != nil {
+		return nil, err
+	}
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var source Source
+	if _, err := s.do(ctx, &source, "POST", "/sources", nil, nil); err != nil {
+		return nil, err
+	}
+	if err := s.upload(ctx, "PUT", source.SourceBlob.PutURL, f, size, nil); err != nil {
+		return nil, err
+	}
+
+	var o BuildCreateOpts
+	o.SourceBlob.URL = String(source.SourceBlob.GetURL)
+	build := new(Build)
+	if _, err := s.do(ctx, build, "POST", fmt.Sprintf("/apps/%v/builds", pathEscape(appIdentity)), o, nil); err != nil {
+		return nil, err
+	}
+	if output != nil && build.OutputStreamURL != "" {
+		req, err := s.newBlobRequest("GET", build.OutputStreamURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.send(output, req.WithContext(ctx)); err != nil {
+			return nil, fmt.Errorf("heroku: streaming output of build %s: %v", build.ID, err)
+		}
+	}
+
+	timeout := buildTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	err = poll(ctx, fmt.Sprintf("build %s to finish", build.ID), timeout, func() (bool, error) {
+		b := new(Build)
+		if _, err := s.do(ctx, b, "GET", fmt.Sprintf("/apps/%v/builds/%v", pathEscape(appIdentity), pathEscape(build.ID)), nil, nil); err != nil {
+			return false, err
+		}
+		switch b.Status {
+		case "failed":
+			return false, fmt.Errorf("heroku: build %s failed, see BuildResultInfo for its output", b.ID)
+		case "succeeded":
+			// The release may be created shortly after the build.
+			build = b
+			return b.Release != nil, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var release Release
+	if _, err := s.do(ctx, &release, "GET", fmt.Sprintf("/apps/%v/releases/%v", pathEscape(appIdentity), pathEscape(build.Release.ID)), nil, nil); err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
+
+// tarDir writes the files of dir as a gzipped tarball to w.
+func tarDir(w io.Writer, dir string) error {
+	var ignore ignorePatterns
+	for _, name := range []string{".slugignore", ".gitignore"} {
+		if err := ignore.read(filepath.Join(dir, name)); err != nil {
+			return err
+		}
+	}
+
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == ".git" || ignore.match(rel, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = rel
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// ignorePattern is a pattern of a .gitignore-style file.
+type ignorePattern struct {
+	pattern  string
+	anchored bool // match against the path relative to the root
+	dirOnly  bool // only match directories
+}
+
+type ignorePatterns []ignorePattern
+
+// read adds the patterns of the file at name, if it exists.
+func (ps *ignorePatterns) read(name string) error {
+	f, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") {
+			continue
+		}
+		var p ignorePattern
+		if strings.HasSuffix(line, "/") {
+			p.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			p.anchored = true
+			line = strings.TrimPrefix(line, "/")
+		}
+		p.pattern = line
+		*ps = append(*ps, p)
+	}
+	return sc.Err()
+}
+
+// match reports whether the slash-separated path rel, relative to the
+// root, matches one of the patterns.
+func (ps ignorePatterns) match(rel string, isDir bool) bool {
+	for _, p := range ps {
+		if p.dirOnly && !isDir {
+			continue
+		}
+		name := path.Base(rel)
+		if p.anchored {
+			name = rel
+		}
+		if matchSegments(strings.Split(p.pattern, "/"), strings.Split(name, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments reports whether the segments of a path match those of a
+// pattern, where a ** segment matches any number of segments and the
+// others are matched with path.Match.
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,51 @@
+package heroku
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DeployBlockedError is returned for requests that change what an app
+// runs when Service.DeployGuard does not allow them.
+type DeployBlockedError struct {
+	Method string // HTTP method of the blocked request
+	Path   string // path of the blocked request
+	Reason string // reason given by DeployGuard
+}
+
+func (e *DeployBlockedError) Error() string {
+	return fmt.Sprintf("heroku: %s %s blocked by deploy guard: %s", e.Method, e.Path, e.Reason)
+}
+
+// IsDeployBlocked reports whether err is caused by Service.DeployGuard
+// blocking a request.
+func IsDeployBlocked(err error) bool {
+	_, ok := err.(*DeployBlockedError)
+	return ok
+}
+
+// deployPathRe matches the paths of requests guarded by DeployGuard.
+var deployPathRe = regexp.MustCompile(`^/apps/[^/]+/(releases|builds|formation(/[^/]+)?)$`)
+
+// checkDeployGuard returns a DeployBlockedError if a request with the
+// given method and path creates a release or a build, or scales the app,
+// and guard does not allow it.
+func checkDeployGuard(guard func() (bool, string), method, path string) error {
+	if guard == nil {
+		return nil
+	}
+	m := deployPathRe.FindStringSubmatch(path)
+	if m == nil {
+		return nil
+	}
+	switch {
+	case method == "POST" && (m[1] == "releases" || m[1] == "builds"):
+	case method == "PATCH" && m[1] != "releases" && m[1] != "builds":
+	default:
+		return nil
+	}
+	if ok, reason := guard(); !ok {
+		return &DeployBlockedError{Method: method, Path: path, Reason: reason}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package heroku
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestIgnorePatterns(t *testing.T) {
+	tests := []struct {
+		pattern string
+		rel     string
+		isDir   bool
+		want    bool
+	}{
+		// Patterns without a slash match at any level.
+		{"*.log", "debug.log", false, true},
+		{"*.log", "log/nested/debug.log", false, true},
+		{"*.log", "debug.log.txt", false, false},
+
+		// Patterns with a slash are anchored to the root.
+		{"/build", "build", true, true},
+		{"/build", "src/build", true, false},
+		{"doc/*.html", "doc/index.html", false, true},
+		{"doc/*.html", "src/doc/index.html", false, false},
+		{"doc/*.html", "doc/api/index.html", false, false},
+
+		// Patterns ending with a slash only match directories.
+		{"tmp/", "tmp", true, true},
+		{"tmp/", "tmp", false, false},
+		{"tmp/", "src/tmp", true, true},
+
+		// ** matches any number of directories.
+		{"**/fixtures", "fixtures", true, true},
+		{"**/fixtures", "test/unit/fixtures", true, true},
+		{"doc/**/*.html", "doc/index.html", false, true},
+		{"doc/**/*.html", "doc/api/v3/index.html", false, true},
+		{"doc/**/*.html", "src/doc/index.html", false, false},
+		{"vendor/**", "vendor/lib/a.go", false, true},
+		{"vendor/**", "src/vendor/a.go", false, false},
+	}
+	for _, tt := range tests {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("# comment\n\n"+tt.pattern+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		var ps ignorePatterns
+		if err := ps.read(filepath.Join(dir, ".gitignore")); err != nil {
+			t.Fatal(err)
+		}
+		if got := ps.match(tt.rel, tt.isDir); got != tt.want {
+			t.Errorf("%q matching %q (dir %v) = %v, want %v", tt.pattern, tt.rel, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestTarDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		".git/config":        "[core]",
+		".gitignore":         "*.log\n/tmp/\n",
+		".slugignore":        "docs/\n",
+		"Procfile":           "web: ./server",
+		"debug.log":          "ignored",
+		"docs/index.html":    "ignored",
+		"src/main.go":        "package main",
+		"src/nested/app.log": "ignored",
+		"src/nested/util.go": "package nested",
+		"src/tmp/keep.go":    "package tmp",
+		"tmp/cache":          "ignored",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tarDir(&buf, dir); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(zr)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+	}
+	sort.Strings(names)
+	want := []string{
+		".gitignore",
+		".slugignore",
+		"Procfile",
+		"src/",
+		"src/main.go",
+		"src/nested/",
+		"src/nested/util.go",
+		"src/tmp/",
+		"src/tmp/keep.go",
+	}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("tarball holds %q, want %q", names, want)
+	}
+}
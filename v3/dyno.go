@@ -0,0 +1,148 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// reservedDynoEnv are config vars set by the platform on every dyno,
+// which cannot be overridden when creating a dyno.
+var reservedDynoEnv = []string{"DYNO", "PORT"}
+
+// prepareDynoCreate validates o and, if s.DynoSizeFromFormation is set,
+// defaults its size to the one of the web process type.
+func (s *Service) prepareDynoCreate(appIdentity string, o *DynoCreateOpts) error {
+	if o.Env != nil {
+		for _, k := range reservedDynoEnv {
+			if _, ok := (*o.Env)[k]; ok {
+				return fmt.Errorf("heroku: %s is set by the platform and cannot be overridden", k)
+			}
+		}
+	}
+	if o.Size == nil && s.DynoSizeFromFormation {
+		f, err := s.FormationInfo(appIdentity, "web")
+		switch {
+		case err == nil:
+			o.Size = String(f.Size)
+		case !IsNotFound(err):
+			return err
+		}
+	}
+	return nil
+}
+
+// DynoRestartAndWait restarts a dyno and waits until it is up again, or
+// idle if the app sleeps when inactive. It fails if the dyno crashes, or
+// with a TimeoutError if it is not up within timeout.
+func (s *Service) DynoRestartAndWait(appIdentity, dynoIdentity string, timeout time.Duration) (*Dyno, error) {
+	return s.DynoRestartAndWaitWithContext(context.Background(), appIdentity, dynoIdentity, timeout)
+}
+
+// DynoRestartAndWaitWithContext is like DynoRestartAndWait but its
+// requests are bound to ctx, and it stops waiting and returns the error
+// of ctx once ctx is canceled.
+func (s *Service) DynoRestartAndWaitWithContext(ctx context.Context, appIdentity, dynoIdentity string, timeout time.Duration) (*Dyno, error) {
+	var before Dyno
+	if _, err := s.do(ctx, &before, "GET", fmt.Sprintf("/apps/%v/dynos/%v", pathEscape(appIdentity), pathEscape(dynoIdentity)), nil, nil); err != nil {
+		return nil, err
+	}
+	if _, err := s.do(ctx, nil, "DELETE", fmt.Sprintf("/apps/%v/dynos/%v", pathEscape(appIdentity), pathEscape(dynoIdentity)), nil, nil); err != nil {
+		return nil, err
+	}
+	var dyno *Dyno
+	err := poll(ctx, fmt.Sprintf("dyno %s to be up", before.Name), timeout, func() (bool, error) {
+		d := new(Dyno)
+		_, err := s.do(ctx, d, "GET", fmt.Sprintf("/apps/%v/dynos/%v", pathEscape(appIdentity), pathEscape(before.Name)), nil, nil)
+		if IsNotFound(err) {
+			// The dyno is being replaced.
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if d.ID == before.ID && !d.UpdatedAt.After(before.UpdatedAt) {
+			// The restart has not been picked up yet.
+			return false, nil
+		}
+		switch d.State {
+		case "up", "idle":
+			dyno = d
+			return true, nil
+		case "crashed":
+			return false, fmt.Errorf("heroku: dyno %s crashed after restart", d.Name)
+		}
+		return false, nil
+	})
+	return dyno, err
+}
+
+// DynoSummary counts the dynos of the app by process type and state,
+// e.g. summary["web"]["up"]. All pages of the dyno list are counted.
+func (s *Service) DynoSummary(appIdentity string) (map[string]map[string]int, error) {
+	summary := make(map[string]map[string]int)
+	var lr *ListRange
+	for {
+		var dynoList []*Dyno
+		resp, err := s.do(context.Background(), &dynoList, "GET", fmt.Sprintf("/apps/%v/dynos", pathEscape(appIdentity)), nil, lr)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range dynoList {
+			if summary[d.Type] == nil {
+				summary[d.Type] = make(map[string]int)
+			}
+			summary[d.Type][d.State]++
+		}
+		if lr = newCursor(resp).Next(); lr == nil {
+			return summary, nil
+		}
+	}
+}
+
+// RendezvousEndpoint is where to connect to attach to a dyno created with
+// Attach set, as given by its attach URL, e.g.
+// rendezvous://rendezvous.runtime.heroku.com:5000/{secret}. Secret grants
+// access to the dyno; String leaves it out, so that endpoints can be
+// printed and logged.
+//
+// The attach URL holding the secret is kept out of logs by two policies:
+// DefaultRedactor masks the attach_url field of bodies logged at
+// LogBodies, and Transport.Debug dumps and error snippets leave out the
+// bodies of all dyno requests. A custom Service.Redactor must keep
+// masking attach_url.
+type RendezvousEndpoint struct {
+	Host   string // host to connect to
+	Port   int    // port to connect to
+	Secret string // secret to send first once connected
+}
+
+func (e RendezvousEndpoint) String() string {
+	return fmt.Sprintf("rendezvous://%s/[redacted]", net.JoinHostPort(e.Host, strconv.Itoa(e.Port)))
+}
+
+// Rendezvous parses the attach URL of the dyno. It fails if the dyno was
+// not created with Attach set.
+func (d Dyno) Rendezvous() (*RendezvousEndpoint, error) {
+	if d.AttachURL == nil {
+		return nil, fmt.Errorf("heroku: dyno %s has no attach URL", d.Name)
+	}
+	u, err := url.Parse(*d.AttachURL)
+	if err != nil {
+		// The error would hold the URL and its secret.
+		return nil, fmt.Errorf("heroku: invalid attach URL of dyno %s", d.Name)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if u.Scheme != "rendezvous" || err != nil || u.Hostname() == "" {
+		return nil, fmt.Errorf("heroku: invalid attach URL of dyno %s", d.Name)
+	}
+	return &RendezvousEndpoint{
+		Host:   u.Hostname(),
+		Port:   port,
+		Secret: strings.TrimPrefix(u.Path, "/"),
+	}, nil
+}
@@ -0,0 +1,53 @@
+package heroku
+
+// DynoSizeName is the name of a dyno size, as accepted by
+// FormationUpdateSize and DynoCreateWithSize. Use Service.DynoSizeName to
+// obtain one checked against the sizes offered by the API; a size not
+// known yet can still be given as DynoSizeName("name"), or as a plain
+// string in the Size of FormationUpdateOpts or DynoCreateOpts.
+type DynoSizeName string
+
+// Dyno sizes.
+const (
+	DynoSize1X DynoSizeName = "1X"
+	DynoSize2X DynoSizeName = "2X"
+	DynoSizePX DynoSizeName = "PX"
+)
+
+// Ptr returns a pointer to the name, to set as the Size of options such
+// as FormationBatchUpdateOpts.
+func (n DynoSizeName) Ptr() *string {
+	return String(string(n))
+}
+
+// DynoSizeName returns the name of the dyno size with the given id or
+// name, checked against DynoSizeList. It fails for sizes not offered by
+// the API instead of having the request using it rejected. The list of
+// sizes is fetched once and cached.
+func (s *Service) DynoSizeName(size string) (DynoSizeName, error) {
+	name, err := s.dynoSizeCache.lookup("dyno size", size, func() ([]identity, error) {
+		sizes, err := s.DynoSizeList(nil)
+		ids := make([]identity, len(sizes))
+		for i, d := range sizes {
+			ids[i] = identity{d.ID, d.Name}
+		}
+		return ids, err
+	})
+	return DynoSizeName(name), err
+}
+
+// FormationUpdateSize changes the dyno size of a process type:
+//
+//	size, err := s.DynoSizeName("2X")
+//	...
+//	f, err := s.FormationUpdateSize("example", "web", size)
+func (s *Service) FormationUpdateSize(appIdentity, formationIdentity string, size DynoSizeName) (*Formation, error) {
+	return s.FormationUpdate(appIdentity, formationIdentity, FormationUpdateOpts{Size: size.Ptr()})
+}
+
+// DynoCreateWithSize is like DynoCreate but creates a dyno of the given
+// size, overriding o.Size.
+func (s *Service) DynoCreateWithSize(appIdentity string, size DynoSizeName, o DynoCreateOpts) (*Dyno, error) {
+	o.Size = size.Ptr()
+	return s.DynoCreate(appIdentity, o)
+}
@@ -0,0 +1,40 @@
+package heroku
+
+import (
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestDynoSizeNameCache(t *testing.T) {
+	var requests int
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	for i := 0; i < 2; i++ {
+		if _, err := s.DynoSizeName("2X"); err == nil {
+			t.Error("DynoSizeName with no sizes offered: no error")
+		}
+	}
+	if requests != 1 {
+		t.Errorf("sizes fetched %d times, want once even when empty", requests)
+	}
+}
+
+func TestFormationUpdateSize(t *testing.T) {
+	var body string
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"size":"2X"}`))
+	}))
+	if _, err := s.FormationUpdateSize("example", "web", DynoSize2X); err != nil {
+		t.Fatal(err)
+	}
+	if body != "{\"size\":\"2X\"}\n" {
+		t.Errorf("request body = %q", body)
+	}
+}
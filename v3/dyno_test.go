@@ -0,0 +1,44 @@
+package heroku
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDynoSummary(t *testing.T) {
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apps/example/dynos" {
+			t.Errorf("unexpected request for %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Range") == "" {
+			w.Header().Set("Next-Range", "]web.2..")
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte(`[
+				{"name":"web.1","type":"web","state":"up"},
+				{"name":"web.2","type":"web","state":"crashed"}
+			]`))
+			return
+		}
+		if got := r.Header.Get("Range"); got != "]web.2.." {
+			t.Errorf("second page Range = %q", got)
+		}
+		w.Write([]byte(`[
+			{"name":"web.3","type":"web","state":"up"},
+			{"name":"worker.1","type":"worker","state":"starting"}
+		]`))
+	}))
+
+	summary, err := s.DynoSummary("example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[string]int{
+		"web":    {"up": 2, "crashed": 1},
+		"worker": {"starting": 1},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("DynoSummary = %v, want %v", summary, want)
+	}
+}
@@ -0,0 +1,221 @@
+package heroku
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Error is returned for API responses with a non-2xx status code.
+type Error struct {
+	error
+	ID         string // error id, e.g. "not_found"
+	URL        string // URL to documentation about the error, if any
+	StatusCode int    // HTTP status code of the response
+	RequestID  string // Request-Id of the response, to quote when contacting support
+}
+
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode/100 != 2 { // 200, 201, 202, etc
+		if !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+			// Not an API error but e.g. an HTML maintenance page
+			// served by the router.
+			msg := fmt.Sprintf("encountered an error : %s", resp.Status)
+			snippet, _ := io.ReadAll(io.LimitReader(resp.Body, snippetSize))
+			if len(snippet) > 0 && !sensitiveResponse(resp) {
+				msg += fmt.Sprintf(": %q", snippet)
+			}
+			return Error{
+				error:      errors.New(msg),
+				StatusCode: resp.StatusCode,
+				RequestID:  resp.Header.Get("Request-Id"),
+			}
+		}
+		var e struct {
+			Message string
+			ID      string
+			URL     string `json:"url"`
+		}
+		err := json.NewDecoder(resp.Body).Decode(&e)
+		if err != nil {
+			return Error{
+				error:      fmt.Errorf("encountered an error : %s", resp.Status),
+				StatusCode: resp.StatusCode,
+				RequestID:  resp.Header.Get("Request-Id"),
+			}
+		}
+		return Error{
+			error:      errors.New(e.Message),
+			ID:         e.ID,
+			URL:        e.URL,
+			StatusCode: resp.StatusCode,
+			RequestID:  resp.Header.Get("Request-Id"),
+		}
+	}
+	return nil
+}
+
+// asError extracts an Error from err, which is either an Error itself or
+// an Error returned by Transport and wrapped by http.Client.
+func asError(err error) (Error, bool) {
+	if ue, ok := err.(*url.Error); ok {
+		err = ue.Err
+	}
+	e, ok := err.(Error)
+	return e, ok
+}
+
+// IsNotFound reports whether err is an API error caused by a resource
+// that does not exist, such as deleting an add-on that is already gone.
+func IsNotFound(err error) bool {
+	e, ok := asError(err)
+	return ok && (e.StatusCode == http.StatusNotFound || e.ID == "not_found")
+}
+
+// snippetSize is the number of body bytes kept for a DecodeError.
+const snippetSize = 256
+
+// DecodeError is returned when a successful response could not be
+// decoded, e.g. because an HTML page was served instead of JSON.
+type DecodeError struct {
+	StatusCode  int    // HTTP status code of the response
+	ContentType string // Content-Type of the response
+	Snippet     string // beginning of the body, empty for sensitive resources
+	Err         error  // error returned by the JSON decoder
+}
+
+func (e *DecodeError) Error() string {
+	msg := fmt.Sprintf("heroku: decoding %d response (%s): %v", e.StatusCode, e.ContentType, e.Err)
+	if e.Snippet != "" {
+		msg += fmt.Sprintf(": %q", e.Snippet)
+	}
+	return msg
+}
+
+func newDecodeError(resp *http.Response, snippet []byte, err error) *DecodeError {
+	e := &DecodeError{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Err:         err,
+	}
+	if !sensitiveResponse(resp) {
+		e.Snippet = string(snippet)
+	}
+	return e
+}
+
+// snippetWriter keeps the first max bytes written to it.
+type snippetWriter struct {
+	buf []byte
+	max int
+}
+
+func (w *snippetWriter) Write(p []byte) (int, error) {
+	if n := w.max - len(w.buf); n > 0 {
+		if n > len(p) {
+			n = len(p)
+		}
+		w.buf = append(w.buf, p[:n]...)
+	}
+	return len(p), nil
+}
+
+// IsConflict reports whether err is an API error caused by a concurrent
+// modification, such as a failed If-Match precondition.
+func IsConflict(err error) bool {
+	e, ok := asError(err)
+	return ok && (e.StatusCode == http.StatusConflict || e.StatusCode == http.StatusPreconditionFailed)
+}
+
+// IsConfirmationRequired reports whether err is caused by an operation
+// that must be confirmed, such as provisioning an add-on that incurs
+// costs. The operation can be retried with the app name as confirmation,
+// e.g. in AddonCreateOpts.Confirm.
+//
+// Like the other API errors, it is an Error recognized by a predicate
+// rather than a distinct type or sentinel, so that its message, status
+// code and request id remain available.
+func IsConfirmationRequired(err error) bool {
+	e, ok := asError(err)
+	return ok && e.ID == "confirmation_required"
+}
+
+// IsForbidden reports whether err is caused by the token lacking access
+// to a resource, e.g. a personal token acting on an organization app of
+// which the account is not a member. Organization resources that are not
+// visible at all are reported as not found instead; see IsNotFound.
+func IsForbidden(err error) bool {
+	e, ok := asError(err)
+	return ok && (e.StatusCode == http.StatusForbidden || e.ID == "forbidden")
+}
+
+// IsServiceUnavailable reports whether err is caused by the API being
+// temporarily unavailable, e.g. during maintenance. Such requests may be
+// retried later.
+func IsServiceUnavailable(err error) bool {
+	e, ok := asError(err)
+	return ok && e.StatusCode == http.StatusServiceUnavailable
+}
+
+// IsDelinquent reports whether err is caused by the account being
+// delinquent or suspended for non-payment. Operations that incur costs,
+// such as scaling or provisioning add-ons, are blocked until the account
+// is settled; see Account.DelinquentAt and Account.SuspendedAt.
+func IsDelinquent(err error) bool {
+	e, ok := asError(err)
+	if !ok {
+		return false
+	}
+	switch e.ID {
+	case "delinquent", "suspended":
+		return true
+	}
+	return e.StatusCode == http.StatusPaymentRequired
+}
+
+// quotaLimitRe extracts the limit from quota error messages such as
+// "You've reached the limit of 100 apps for unverified accounts".
+var quotaLimitRe = regexp.MustCompile(`limit of (\d+)`)
+
+// IsQuotaExceeded reports whether err is caused by the account reaching
+// one of its limits, such as the maximum number of apps on AppCreate.
+func IsQuotaExceeded(err error) bool {
+	e, ok := asError(err)
+	if !ok {
+		return false
+	}
+	switch e.ID {
+	case "app_limit", "quota_exceeded":
+		return true
+	}
+	return e.StatusCode == http.StatusUnprocessableEntity && quotaLimitRe.MatchString(e.Error())
+}
+
+// QuotaLimit returns the limit reported by a quota error, if any.
+func QuotaLimit(err error) (int, bool) {
+	if !IsQuotaExceeded(err) {
+		return 0, false
+	}
+	m := quotaLimitRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[1])
+	return n, err == nil
+}
+
+// isTransient reports whether err is a network error or a server error,
+// after which a request may succeed when retried.
+func isTransient(err error) bool {
+	if e, ok := asError(err); ok {
+		return e.StatusCode >= 500
+	}
+	_, ok := err.(*url.Error)
+	return ok
+}
@@ -0,0 +1,39 @@
+package heroku
+
+// exists translates the error of an info call into an existence check:
+// a not found error means the resource does not exist.
+func exists(err error) (bool, error) {
+	switch {
+	case err == nil:
+		return true, nil
+	case IsNotFound(err):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// AppExists reports whether the app exists.
+func (s *Service) AppExists(appIdentity string) (bool, error) {
+	_, err := s.AppInfo(appIdentity)
+	return exists(err)
+}
+
+// AddonExists reports whether the add-on exists on the app.
+func (s *Service) AddonExists(appIdentity, addonIdentity string) (bool, error) {
+	_, err := s.AddonInfo(appIdentity, addonIdentity)
+	return exists(err)
+}
+
+// CollaboratorExists reports whether the account, given by email or id,
+// is a collaborator on the app.
+func (s *Service) CollaboratorExists(appIdentity, collaboratorIdentity string) (bool, error) {
+	_, err := s.CollaboratorInfo(appIdentity, collaboratorIdentity)
+	return exists(err)
+}
+
+// DomainExists reports whether the hostname is added to the app.
+func (s *Service) DomainExists(appIdentity, hostname string) (bool, error) {
+	_, err := s.DomainInfo(appIdentity, hostname)
+	return exists(err)
+}
@@ -0,0 +1,44 @@
+package heroku
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// FlexibleInt is an integer decoded from either a JSON number or a JSON
+// string holding one, such as 10 or "10". It is used for fields whose
+// representation has varied between versions of the API, so that a
+// change does not break decoding. It is encoded as a number.
+type FlexibleInt int
+
+// UnmarshalJSON decodes a JSON number, or a JSON string holding one, into
+// n. Numbers with a zero fractional part, such as 10.0, are accepted; any
+// other fraction is an error. null leaves n unchanged.
+func (n *FlexibleInt) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		b = []byte(s)
+	}
+	var num json.Number
+	if err := json.Unmarshal(b, &num); err != nil {
+		return fmt.Errorf("heroku: invalid integer %s", b)
+	}
+	i, err := num.Int64()
+	if err != nil {
+		// Integers sent as floats, such as 10.0.
+		f, ferr := num.Float64()
+		if ferr != nil || f != float64(int64(f)) {
+			return fmt.Errorf("heroku: invalid integer %s", b)
+		}
+		i = int64(f)
+	}
+	*n = FlexibleInt(i)
+	return nil
+}
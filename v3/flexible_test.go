@@ -0,0 +1,71 @@
+package heroku
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlexibleIntUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json    string
+		want    FlexibleInt
+		wantErr bool
+	}{
+		{`10`, 10, false},
+		{`"10"`, 10, false},
+		{`10.0`, 10, false},
+		{`null`, 7, false}, // left unchanged
+		{`"abc"`, 0, true},
+		{`10.5`, 0, true},
+	}
+	for _, tt := range tests {
+		n := FlexibleInt(7)
+		err := json.Unmarshal([]byte(tt.json), &n)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: decoded %d, want an error", tt.json, n)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.json, err)
+			continue
+		}
+		if n != tt.want {
+			t.Errorf("%s: decoded %d, want %d", tt.json, n, tt.want)
+		}
+	}
+}
+
+func TestFlexibleIntFields(t *testing.T) {
+	for _, js := range []string{`{"quantity":2}`, `{"quantity":"2"}`} {
+		var f Formation
+		if err := json.Unmarshal([]byte(js), &f); err != nil {
+			t.Errorf("Formation %s: %v", js, err)
+			continue
+		}
+		if f.Quantity != 2 {
+			t.Errorf("Formation %s: Quantity = %d, want 2", js, f.Quantity)
+		}
+	}
+	for _, js := range []string{`{"price":{"cents":500,"unit":"month"}}`, `{"price":{"cents":"500","unit":"month"}}`} {
+		var p Plan
+		if err := json.Unmarshal([]byte(js), &p); err != nil {
+			t.Errorf("Plan %s: %v", js, err)
+			continue
+		}
+		if p.Price.Cents != 500 {
+			t.Errorf("Plan %s: Price.Cents = %d, want 500", js, p.Price.Cents)
+		}
+	}
+}
+
+func TestFlexibleIntMarshalJSON(t *testing.T) {
+	b, err := json.Marshal(FlexibleInt(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "10" {
+		t.Errorf("encoded %s, want 10", b)
+	}
+}
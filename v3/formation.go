@@ -0,0 +1,74 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// FormationScaleAndWait scales the given process types to the given
+// quantities and waits until exactly that many dynos of each type are
+// up, or idle: dynos of apps that sleep when inactive idle until they
+// receive a request, and are as settled as running ones. It fails if a dyno of these types crashes, or with a TimeoutError
+// if the dynos are not running within timeout.
+func (s *Service) FormationScaleAndWait(appIdentity string, scales map[string]int, timeout time.Duration) ([]*Formation, error) {
+	return s.FormationScaleAndWaitWithContext(context.Background(), appIdentity, scales, timeout)
+}
+
+// FormationScaleAndWaitWithContext is like FormationScaleAndWait but its
+// requests are bound to ctx, and it stops waiting and returns the error
+// of ctx once ctx is canceled.
+func (s *Service) FormationScaleAndWaitWithContext(ctx context.Context, appIdentity string, scales map[string]int, timeout time.Duration) ([]*Formation, error) {
+	types := make([]string, 0, len(scales))
+	for t := range scales {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	var o FormationBatchUpdateOpts
+	for _, t := range types {
+		var u struct {
+			Process  string  `json:"process"`
+			Quantity *int    `json:"quantity,omitempty"`
+			Size     *string `json:"size,omitempty"`
+		}
+		u.Process = t
+		u.Quantity = Int(scales[t])
+		o.Updates = append(o.Updates, u)
+	}
+	var formations []*Formation
+	if _, err := s.do(ctx, &formations, "PATCH", fmt.Sprintf("/apps/%v/formation", pathEscape(appIdentity)), o, nil); err != nil {
+		return nil, err
+	}
+
+	err := poll(ctx, fmt.Sprintf("formation of %s to be running", appIdentity), timeout, func() (bool, error) {
+		var dynos []*Dyno
+		if _, err := s.do(ctx, &dynos, "GET", fmt.Sprintf("/apps/%v/dynos", pathEscape(appIdentity)), nil, nil); err != nil {
+			return false, err
+		}
+		running := make(map[string]int)
+		for _, d := range dynos {
+			if _, ok := scales[d.Type]; !ok {
+				continue
+			}
+			switch d.State {
+			case "crashed":
+				return false, fmt.Errorf("heroku: dyno %s crashed while scaling", d.Name)
+			case "up", "idle":
+				running[d.Type]++
+			default:
+				// Dynos that are starting, or shutting down after
+				// scaling down, are waited for.
+				return false, nil
+			}
+		}
+		for t, n := range scales {
+			if running[t] != n {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	return formations, err
+}
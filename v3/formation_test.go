@@ -0,0 +1,27 @@
+package heroku
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFormationScaleAndWaitIdle(t *testing.T) {
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "PATCH /apps/example/formation":
+			w.Write([]byte(`[{"type":"web","quantity":2}]`))
+		case "GET /apps/example/dynos":
+			w.Write([]byte(`[
+				{"name":"web.1","type":"web","state":"up"},
+				{"name":"web.2","type":"web","state":"idle"}
+			]`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	if _, err := s.FormationScaleAndWait("example", map[string]int{"web": 2}, time.Second); err != nil {
+		t.Errorf("FormationScaleAndWait with an idle dyno: %v", err)
+	}
+}
@@ -0,0 +1,563 @@
+//go:build ignore
+// +build ignore
+
+// Gen generates heroku.go from the Heroku Platform API JSON schema:
+//
+//	go run gen.go -o heroku.go schema.json
+//
+// The published schema can be fetched with:
+//
+//	curl -H 'Accept: application/vnd.heroku+json; version=3' https://api.heroku.com/schema > schema.json
+//
+// Properties whose schema type includes "null" are generated as pointers
+// in resources. In request bodies every property that is not required is
+// generated with omitempty and, unless it is an array, as a pointer, so
+// that zero values such as false or 0 can be sent while nil leaves the
+// property out.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// extras are additions to generated methods. Doc is appended to the
+// method comment and Hooks are statements run before the request is
+// sent, calling hand-written code in other files of the package.
+var extras = map[string]struct {
+	Doc   string
+	Hooks []string
+}{
+	// Preparation of app creation belongs in prepareAppCreate, which
+	// AppCreateIdempotent calls too, rather than in more hooks here.
+	"AppCreate": {Hooks: []string{
+		"if err := s.prepareAppCreate(&o.Region, &o.Stack); err != nil {\nreturn nil, err\n}",
+	}},
+	"AppFeatureList": {
+		Doc: "All features available to the app are listed, whether they are enabled or not; see AppFeatureListEnabled for enabled features only.",
+	},
+	"DynoCreate": {
+		Doc: "The DYNO and PORT config vars cannot be set through Env. With Attach set, Rendezvous of the returned dyno gives the endpoint to connect to.",
+		Hooks: []string{
+			"if err := s.prepareDynoCreate(appIdentity, (*DynoCreateOpts)(&o)); err != nil {\nreturn nil, err\n}",
+		},
+	},
+	"LogSessionCreate": {
+		Doc: "Source may be LogSourceApp or LogSourceHeroku, Dyno a process type (web) or dyno name (web.1), and Lines at most MaxLogLines. Invalid options are rejected before the request is sent.",
+		Hooks: []string{
+			"if err := validateLogSession(o); err != nil {\nreturn nil, err\n}",
+		},
+	},
+	"OAuthAuthorizationCreate": {
+		Doc: "If Scope is nil, the API applies its default scope, global; an explicit scope, e.g. heroku.Strings(heroku.ScopeRead), is always sent.",
+	},
+	"OrganizationAppCreate": {Hooks: []string{
+		"if o.Organization == nil && s.DefaultOrganization != \"\" {\no.Organization = String(s.DefaultOrganization)\n}",
+		"if err := s.prepareAppCreate(&o.Region, &o.Stack); err != nil {\nreturn nil, err\n}",
+	}},
+	"OrganizationAppList": {
+		Doc: "If DefaultOrganization is set, apps of that organization are listed instead.",
+		Hooks: []string{
+			"if s.DefaultOrganization != \"\" {\nreturn s.OrganizationAppListForOrganization(s.DefaultOrganization, lr)\n}",
+		},
+	},
+	"ReleaseRollback": {
+		Doc: "Without a Description, the new release is described as a rollback to the version of the release. Set it to record who rolled back and why; the User of the new release is the account of the token.",
+	},
+	"SlugCreate": {
+		Doc: "Slugs are immutable and cannot be updated once created: to change the process types of a slug, create a new slug with the new process types and upload the same tarball to it, as SlugPromote does.",
+	},
+}
+
+// typeOverrides are Go types used for the properties of resources with
+// the given $ref instead of the type of their schema, e.g. for fields
+// whose JSON representation has varied.
+var typeOverrides = map[string]string{
+	"#/definitions/formation/definitions/quantity": "FlexibleInt",
+	"#/definitions/plan/definitions/cents":         "FlexibleInt",
+}
+
+// optsTypeOverrides are like typeOverrides but for the properties of
+// request bodies.
+var optsTypeOverrides = map[string]string{
+	// A pointer, so that an explicit empty scope is sent rather than
+	// left out.
+	"#/definitions/oauth-authorization/definitions/scope": "*[]string",
+}
+
+// optionalOverrides are properties of request bodies that are left out
+// when nil even though the schema requires them, as the API defaults them.
+var optionalOverrides = map[string]bool{
+	// The API defaults to the global scope.
+	"#/definitions/oauth-authorization/definitions/scope": true,
+}
+
+// initialisms are words spelled in a fixed case in Go names.
+var initialisms = map[string]string{
+	"api":   "API",
+	"cname": "CName",
+	"http":  "HTTP",
+	"id":    "ID",
+	"ip":    "IP",
+	"json":  "JSON",
+	"oauth": "OAuth",
+	"sms":   "SMS",
+	"ssl":   "SSL",
+	"sso":   "SSO",
+	"ttl":   "TTL",
+	"uri":   "URI",
+	"url":   "URL",
+	"uuid":  "UUID",
+}
+
+var wordRe = regexp.MustCompile(`[A-Za-z0-9]+`)
+
+// goName returns the exported Go name of a schema name such as
+// "ssl-endpoint", "last_login" or "Change Email".
+func goName(s string) string {
+	var b strings.Builder
+	for _, w := range wordRe.FindAllString(s, -1) {
+		if i, ok := initialisms[strings.ToLower(w)]; ok {
+			b.WriteString(i)
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// varName returns the unexported Go name of a schema name.
+func varName(s string) string {
+	words := wordRe.FindAllString(s, -1)
+	first := strings.ToLower(words[0])
+	return first + goName(strings.Join(words[1:], " "))
+}
+
+// asComment wraps c into comment lines of at most 70 characters.
+func asComment(c string) []string {
+	const maxLen = 70
+	var lines []string
+	c = strings.Replace(c, "\n", " ", -1)
+	for len(c) > 0 {
+		line := c
+		if len(line) < maxLen {
+			lines = append(lines, "// "+line)
+			break
+		}
+		line = line[:maxLen]
+		si := strings.LastIndex(line, " ")
+		if si != -1 {
+			line = line[:si]
+		}
+		lines = append(lines, "// "+line)
+		c = c[len(line):]
+		if si != -1 {
+			c = c[1:]
+		}
+	}
+	return lines
+}
+
+type Schema map[string]interface{}
+
+var root Schema
+
+// resolve follows $ref until s is a plain schema. Of alternatives, such
+// as the id or name identifying a resource, the first one is used.
+func resolve(s Schema) Schema {
+	for {
+		if alts, ok := s["anyOf"].([]interface{}); ok && len(alts) > 0 {
+			s = Schema(alts[0].(map[string]interface{}))
+			continue
+		}
+		ref, ok := s["$ref"].(string)
+		if !ok {
+			return s
+		}
+		s = lookup(ref)
+	}
+}
+
+func lookup(ref string) Schema {
+	var v interface{} = map[string]interface{}(root)
+	for _, k := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			log.Fatalf("gen: invalid reference %s", ref)
+		}
+		v = m[k]
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		log.Fatalf("gen: invalid reference %s", ref)
+	}
+	return Schema(m)
+}
+
+func (s Schema) str(k string) string {
+	v, _ := s[k].(string)
+	return v
+}
+
+func (s Schema) obj(k string) Schema {
+	v, _ := s[k].(map[string]interface{})
+	return Schema(v)
+}
+
+// types returns the types of s without "null" and whether "null" is
+// one of them.
+func (s Schema) types() ([]string, bool) {
+	var types []string
+	nullable := false
+	switch t := s["type"].(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, v := range t {
+			if v == "null" {
+				nullable = true
+				continue
+			}
+			types = append(types, v.(string))
+		}
+	}
+	return types, nullable
+}
+
+func (s Schema) required() map[string]bool {
+	req := make(map[string]bool)
+	if l, ok := s["required"].([]interface{}); ok {
+		for _, v := range l {
+			req[v.(string)] = true
+		}
+	}
+	return req
+}
+
+func (s Schema) properties() []string {
+	var keys []string
+	for k := range s.obj("properties") {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isMap reports whether s is an object with arbitrary keys.
+func (s Schema) isMap() bool {
+	types, _ := s.types()
+	return len(types) == 1 && types[0] == "object" && s["properties"] == nil && s["patternProperties"] != nil
+}
+
+// mapValueNullable reports whether the values of the map s may be null.
+func (s Schema) mapValueNullable() bool {
+	for _, v := range s.obj("patternProperties") {
+		_, nullable := resolve(Schema(v.(map[string]interface{}))).types()
+		return nullable
+	}
+	return false
+}
+
+// generator writes Go source to a buffer.
+type generator struct {
+	bytes.Buffer
+	endpoints []string // endpoint literals of the generated methods
+}
+
+func (g *generator) p(format string, args ...interface{}) {
+	fmt.Fprintf(&g.Buffer, format, args...)
+}
+
+// goType returns the Go type of s. In opts mode, s describes a request
+// body where optional properties are pointers.
+func (g *generator) goType(s Schema, ptr, opts bool) string {
+	s = resolve(s)
+	types, nullable := s.types()
+	if !opts {
+		ptr = nullable
+	}
+	prefix := ""
+	if ptr {
+		prefix = "*"
+	}
+	if len(types) != 1 {
+		return prefix + "interface{}"
+	}
+	switch types[0] {
+	case "string":
+		if s.str("format") == "date-time" {
+			return prefix + "time.Time"
+		}
+		return prefix + "string"
+	case "integer":
+		return prefix + "int"
+	case "number":
+		return prefix + "float64"
+	case "boolean":
+		return prefix + "bool"
+	case "array":
+		// A nil slice already leaves the property out.
+		if opts {
+			prefix = ""
+		}
+		return prefix + "[]" + g.goType(s.obj("items"), false, opts)
+	case "object":
+		if s["properties"] == nil {
+			if s.mapValueNullable() && opts {
+				return prefix + "map[string]*string"
+			}
+			return prefix + "map[string]string"
+		}
+		return prefix + g.structType(s, opts)
+	}
+	return prefix + "interface{}"
+}
+
+// structType returns an anonymous struct type for the properties of s.
+func (g *generator) structType(s Schema, opts bool) string {
+	keys := s.properties()
+	if len(keys) == 0 {
+		return "struct{}"
+	}
+	req := s.required()
+	props := s.obj("properties")
+	var b strings.Builder
+	b.WriteString("struct {\n")
+	for _, k := range keys {
+		raw := Schema(props[k].(map[string]interface{}))
+		p := resolve(raw)
+		tag := k
+		optional := opts && (!req[k] || optionalOverrides[raw.str("$ref")])
+		if optional {
+			tag += ",omitempty"
+		}
+		comment := asComment(p.str("description"))
+		if len(comment) == 0 {
+			comment = []string{""}
+		}
+		overrides := typeOverrides
+		if opts {
+			overrides = optsTypeOverrides
+		}
+		typ, ok := overrides[raw.str("$ref")]
+		if !ok {
+			typ = g.goType(p, optional, opts)
+		}
+		fmt.Fprintf(&b, "%s %s `json:%q` %s\n", goName(k), typ, tag, comment[0])
+		for _, c := range comment[1:] {
+			b.WriteString(c + "\n")
+		}
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+var hrefRe = regexp.MustCompile(`\{\(([^)]+)\)\}`)
+
+// link describes a method generated for a link of a resource.
+type link struct {
+	name     string   // method name
+	params   []string // names of the path parameters
+	path     string   // path with parameters replaced by %v
+	template string   // path with parameters replaced by {resource}
+}
+
+func newLink(resource string, l Schema) link {
+	m := link{name: goName(resource) + goName(l.str("title"))}
+	m.path = hrefRe.ReplaceAllStringFunc(l.str("href"), func(s string) string {
+		ref, err := url.QueryUnescape(hrefRe.FindStringSubmatch(s)[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		// #/definitions/<resource>/definitions/identity
+		parts := strings.Split(ref, "/")
+		m.params = append(m.params, varName(parts[2])+"Identity")
+		return "%v"
+	})
+	m.template = hrefRe.ReplaceAllStringFunc(l.str("href"), func(s string) string {
+		ref, _ := url.QueryUnescape(hrefRe.FindStringSubmatch(s)[1])
+		return "{" + strings.Split(ref, "/")[2] + "}"
+	})
+	return m
+}
+
+func (g *generator) resource(name string, r Schema) {
+	typ := goName(name)
+	for _, c := range asComment(r.str("description")) {
+		g.p("%s\n", c)
+	}
+	isMap := r.isMap()
+	if isMap {
+		g.p("type %s map[string]string\n", typ)
+	} else {
+		g.p("type %s %s\n", typ, g.structType(r, false))
+	}
+	links, _ := r["links"].([]interface{})
+	for i, v := range links {
+		g.link(name, typ, isMap, i == 0, Schema(v.(map[string]interface{})))
+	}
+	g.p("\n")
+}
+
+func (g *generator) link(resource, typ string, isMap, first bool, l Schema) {
+	m := newLink(resource, l)
+	method := strings.ToUpper(l.str("method"))
+	g.endpoints = append(g.endpoints, fmt.Sprintf("{%q, %q, %q, %q}", resource, m.name, method, m.template))
+
+	var args []string
+	for _, p := range m.params {
+		args = append(args, p+" string")
+	}
+	body := "nil"
+	if schema := l.obj("schema"); schema != nil {
+		schema = resolve(schema)
+		var opts string
+		if schema.isMap() {
+			opts = g.goType(schema, false, true)
+		} else {
+			opts = g.structType(schema, true)
+		}
+		if !first {
+			g.p("\n")
+		}
+		g.p("type %sOpts %s\n", m.name, opts)
+		args = append(args, "o "+opts)
+		body = "o"
+	}
+
+	// Links return a list of the resource when they are for its
+	// instances or their target is an array. Resources without
+	// properties, such as filters, return their target's resource.
+	target := l.obj("targetSchema")
+	list := l.str("rel") == "instances"
+	if types, _ := target.types(); len(types) == 1 && types[0] == "array" {
+		list = true
+		target = target.obj("items")
+	}
+	v := varName(resource)
+	if len(resolve(Schema{"$ref": "#/definitions/" + resource}).properties()) == 0 && !isMap {
+		if ref := target.str("$ref"); ref != "" {
+			name := strings.TrimPrefix(ref, "#/definitions/")
+			typ, v = goName(name), varName(name)
+		}
+	}
+	if list && method == "GET" {
+		args = append(args, "lr *ListRange")
+		body = "lr"
+	}
+
+	path := fmt.Sprintf("fmt.Sprintf(%q", m.path)
+	for _, p := range m.params {
+		path += ", pathEscape(" + p + ")"
+	}
+	path += ")"
+
+	g.p("\n")
+	doc := l.str("description")
+	if d := extras[m.name].Doc; d != "" {
+		doc += " " + d
+	}
+	for _, c := range asComment(doc) {
+		g.p("%s\n", c)
+	}
+	sig := fmt.Sprintf("func (s *Service) %s(%s)", m.name, strings.Join(args, ", "))
+	switch {
+	case method == "DELETE":
+		g.p("%s error {\n", sig)
+		g.hooks(m.name)
+		g.p("return s.Delete(%s)\n}\n", path)
+	case list:
+		g.p("%s ([]*%s, error) {\n", sig, typ)
+		g.hooks(m.name)
+		g.p("var %sList []*%s\n", v, typ)
+		g.p("return %sList, s.%s(&%sList, %s, %s)\n}\n", v, call(method), v, path, body)
+	case isMap:
+		g.p("%s (%s, error) {\n", sig, typ)
+		g.hooks(m.name)
+		g.p("var %s %s\n", v, typ)
+		g.p("return %s, s.%s(&%s, %s, %s)\n}\n", v, call(method), v, path, body)
+	default:
+		g.p("%s (*%s, error) {\n", sig, typ)
+		g.hooks(m.name)
+		g.p("var %s %s\n", v, typ)
+		g.p("return &%s, s.%s(&%s, %s, %s)\n}\n", v, call(method), v, path, body)
+	}
+}
+
+func (g *generator) hooks(name string) {
+	for _, h := range extras[name].Hooks {
+		g.p("%s\n", h)
+	}
+}
+
+func call(method string) string {
+	return strings.ToUpper(method[:1]) + strings.ToLower(method[1:])
+}
+
+const header = `// Code generated by gen.go from schema.json; DO NOT EDIT.
+
+package heroku
+
+import (
+	"fmt"
+	"time"
+)
+
+`
+
+func main() {
+	out := flag.String("o", "", "output file (default stdout)")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: go run gen.go [-o heroku.go] schema.json")
+		os.Exit(2)
+	}
+	log.SetFlags(0)
+
+	b, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &root); err != nil {
+		log.Fatal(err)
+	}
+
+	var g generator
+	g.p("%s", header)
+	defs := root.obj("definitions")
+	var names []string
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		g.resource(name, Schema(defs[name].(map[string]interface{})))
+	}
+	g.p("// endpoints are the endpoints of the generated methods.\n")
+	g.p("var endpoints = []endpoint{\n")
+	for _, e := range g.endpoints {
+		g.p("%s,\n", e)
+	}
+	g.p("}\n")
+
+	src, err := format.Source(g.Bytes())
+	if err != nil {
+		log.Fatalf("gen: formatting output: %v\n%s", err, g.Bytes())
+	}
+	if *out == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := os.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,89 @@
+package heroku
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOptsZeroValues(t *testing.T) {
+	tests := []struct {
+		name string
+		opts interface{}
+		want string
+	}{
+		{"AppUpdate maintenance", AppUpdateOpts{Maintenance: Bool(false)}, `"maintenance":false`},
+		{"AppUpdate name", AppUpdateOpts{Name: String("")}, `"name":""`},
+		{"FormationUpdate quantity", FormationUpdateOpts{Quantity: Int(0)}, `"quantity":0`},
+		{"LogSessionCreate lines", LogSessionCreateOpts{Lines: Int(0)}, `"lines":0`},
+		{"LogSessionCreate tail", LogSessionCreateOpts{Tail: Bool(false)}, `"tail":false`},
+		{"CollaboratorCreate silent", CollaboratorCreateOpts{Silent: Bool(false)}, `"silent":false`},
+		{"OrganizationAppCollaboratorCreate silent", OrganizationAppCollaboratorCreateOpts{Silent: Bool(false)}, `"silent":false`},
+		{"AccountUpdate allow_tracking", AccountUpdateOpts{AllowTracking: Bool(false)}, `"allow_tracking":false`},
+		{"AccountUpdate beta", AccountUpdateOpts{Beta: Bool(false)}, `"beta":false`},
+		{"AccountFeatureUpdate enabled", AccountFeatureUpdateOpts{Enabled: false}, `"enabled":false`},
+		{"AppFeatureUpdate enabled", AppFeatureUpdateOpts{Enabled: false}, `"enabled":false`},
+		{"OrganizationAppUpdateLocked locked", OrganizationAppUpdateLockedOpts{Locked: false}, `"locked":false`},
+		{"OrganizationUpdate default", OrganizationUpdateOpts{Default: Bool(false)}, `"default":false`},
+		{"DynoCreate attach", DynoCreateOpts{Attach: Bool(false)}, `"attach":false`},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.opts)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !strings.Contains(string(b), tt.want) {
+			t.Errorf("%s: %s does not contain %s", tt.name, b, tt.want)
+		}
+	}
+}
+
+func TestOptsNilOmitted(t *testing.T) {
+	b, err := json.Marshal(AppUpdateOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); got != "{}" {
+		t.Errorf("AppUpdateOpts{} = %s, want {}", got)
+	}
+	b, err = json.Marshal(FormationUpdateOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); got != "{}" {
+		t.Errorf("FormationUpdateOpts{} = %s, want {}", got)
+	}
+}
+
+func TestNullableTimestamps(t *testing.T) {
+	tests := []struct {
+		json string
+		want *time.Time
+	}{
+		{`{}`, nil},
+		{`{"last_login":null}`, nil},
+		{`{"last_login":"2012-01-01T12:00:00Z"}`, timePtr(time.Date(2012, 1, 1, 12, 0, 0, 0, time.UTC))},
+		{`{"last_login":"0001-01-01T00:00:00Z"}`, timePtr(time.Time{})},
+	}
+	for _, tt := range tests {
+		var a Account
+		if err := json.Unmarshal([]byte(tt.json), &a); err != nil {
+			t.Errorf("%s: %v", tt.json, err)
+			continue
+		}
+		switch {
+		case tt.want == nil && a.LastLogin != nil:
+			t.Errorf("%s: LastLogin = %v, want nil", tt.json, *a.LastLogin)
+		case tt.want != nil && a.LastLogin == nil:
+			t.Errorf("%s: LastLogin = nil, want %v", tt.json, *tt.want)
+		case tt.want != nil && !a.LastLogin.Equal(*tt.want):
+			t.Errorf("%s: LastLogin = %v, want %v", tt.json, *a.LastLogin, *tt.want)
+		}
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
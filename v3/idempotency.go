@@ -0,0 +1,49 @@
+package heroku
+
+import (
+	"context"
+	"net/http"
+
+	"code.google.com/p/go-uuid/uuid"
+)
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx that makes requests sent with
+// it, e.g. using DoWithContext, carry key in their Idempotency-Key header.
+// The API performs a create at most once per key, so a request that
+// failed on the network can be retried with the same key without risking
+// a duplicate resource.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// NewIdempotencyKey returns a new random key for WithIdempotencyKey.
+// The same key must be reused for all attempts of a request.
+func NewIdempotencyKey() string {
+	return uuid.New()
+}
+
+// setIdempotencyKey sets the Idempotency-Key header of req from its
+// context, if any.
+func setIdempotencyKey(req *http.Request) {
+	if key, ok := req.Context().Value(idempotencyKeyContextKey{}).(string); ok && key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+}
+
+// AppCreateIdempotent is like AppCreate but sends key as Idempotency-Key,
+// so that it is safe to call again with the same key after an error:
+//
+//	key := heroku.NewIdempotencyKey()
+//	app, err := s.AppCreateIdempotent(key, o)
+//	if err != nil {
+//		app, err = s.AppCreateIdempotent(key, o)
+//	}
+func (s *Service) AppCreateIdempotent(key string, o AppCreateOpts) (*App, error) {
+	if err := s.prepareAppCreate(&o.Region, &o.Stack); err != nil {
+		return nil, err
+	}
+	var app App
+	return &app, s.DoWithContext(WithIdempotencyKey(context.Background(), key), &app, "POST", "/apps", o, nil)
+}
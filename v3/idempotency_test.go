@@ -0,0 +1,33 @@
+package heroku
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestAppCreateIdempotent(t *testing.T) {
+	var key string
+	var body AppCreateOpts
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key = r.Header.Get("Idempotency-Key")
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Error(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"name":"example"}`))
+	}))
+	s.DefaultRegion = "eu"
+	s.DefaultStack = "cedar-14"
+
+	if _, err := s.AppCreateIdempotent("k3y", AppCreateOpts{Name: String("example")}); err != nil {
+		t.Fatal(err)
+	}
+	if key != "k3y" {
+		t.Errorf("Idempotency-Key = %q, want k3y", key)
+	}
+	if body.Region == nil || *body.Region != "eu" || body.Stack == nil || *body.Stack != "cedar-14" {
+		t.Errorf("region %v and stack %v sent, want the defaults of the Service", body.Region, body.Stack)
+	}
+}
@@ -0,0 +1,32 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+)
+
+// KeyInfoByFingerprint returns the key of the account with the given
+// fingerprint, e.g. to delete a rotated key. Keys are listed page by page
+// until one matches. If none does, the returned error satisfies
+// IsNotFound.
+func (s *Service) KeyInfoByFingerprint(fingerprint string) (*Key, error) {
+	var lr *ListRange
+	for {
+		var keyList []*Key
+		resp, err := s.do(context.Background(), &keyList, "GET", "/account/keys", nil, lr)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keyList {
+			if key.Fingerprint == fingerprint {
+				return key, nil
+			}
+		}
+		if lr = newCursor(resp).Next(); lr == nil {
+			return nil, Error{
+				error: fmt.Errorf("heroku: no key with fingerprint %s", fingerprint),
+				ID:    "not_found",
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+)
+
+// LogDrainReconcile makes the log drains of the app match desiredURLs:
+// missing drains are created and extra ones deleted. All pages of the
+// drain list are compared. Drains created by add-ons cannot be removed
+// through the API; they are left in place and returned so that the
+// caller knows about them.
+func (s *Service) LogDrainReconcile(appIdentity string, desiredURLs []string) ([]*LogDrain, error) {
+	var drains []*LogDrain
+	var lr *ListRange
+	for {
+		var page []*LogDrain
+		resp, err := s.do(context.Background(), &page, "GET", fmt.Sprintf("/apps/%v/log-drains", pathEscape(appIdentity)), nil, lr)
+		if err != nil {
+			return nil, err
+		}
+		drains = append(drains, page...)
+		if lr = newCursor(resp).Next(); lr == nil {
+			break
+		}
+	}
+
+	desired := make(map[string]bool, len(desiredURLs))
+	for _, u := range desiredURLs {
+		desired[u] = true
+	}
+
+	var addonDrains []*LogDrain
+	existing := make(map[string]bool, len(drains))
+	for _, d := range drains {
+		existing[d.URL] = true
+		switch {
+		case d.Addon != nil:
+			addonDrains = append(addonDrains, d)
+		case !desired[d.URL]:
+			if err := s.LogDrainDelete(appIdentity, d.ID); err != nil {
+				return addonDrains, err
+			}
+		}
+	}
+
+	for _, u := range desiredURLs {
+		if existing[u] {
+			continue
+		}
+		if _, err := s.LogDrainCreate(appIdentity, LogDrainCreateOpts{URL: u}); err != nil {
+			return addonDrains, err
+		}
+		existing[u] = true
+	}
+	return addonDrains, nil
+}
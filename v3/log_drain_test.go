@@ -0,0 +1,29 @@
+package heroku
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLogDrainReconcilePages(t *testing.T) {
+	h := &pagedHandler{path: "/apps/example/log-drains", pages: []string{
+		`[{"id":"01","url":"syslog://keep"},{"id":"02","url":"syslog://extra1"}]`,
+		`[{"id":"03","url":"syslog://kept-on-page-2"},{"id":"04","url":"syslog://extra2"},{"id":"05","url":"syslog://addon","addon":{"id":"a1"}}]`,
+	}}
+	s := newTestService(t, h)
+	addonDrains, err := s.LogDrainReconcile("example", []string{"syslog://keep", "syslog://kept-on-page-2", "syslog://new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addonDrains) != 1 || addonDrains[0].ID != "05" {
+		t.Errorf("add-on drains = %v, want drain 05", addonDrains)
+	}
+	want := []string{
+		"DELETE /apps/example/log-drains/02",
+		"DELETE /apps/example/log-drains/04",
+		"POST /apps/example/log-drains",
+	}
+	if got := h.requests(); !reflect.DeepEqual(got, want) {
+		t.Errorf("requests = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,38 @@
+package heroku
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Log sources accepted by LogSessionCreate.
+const (
+	LogSourceApp    = "app"    // logs written by the app's processes
+	LogSourceHeroku = "heroku" // logs written by the platform (router, dyno manager, ...)
+)
+
+// MaxLogLines is the largest number of lines a log session can return.
+const MaxLogLines = 1500
+
+// dynoNameRe matches a process type ("web") or dyno name ("web.1").
+var dynoNameRe = regexp.MustCompile(`^[a-zA-Z0-9_-]+(\.[0-9]+)?$`)
+
+func validateLogSession(o LogSessionCreateOpts) error {
+	if o.Source != nil {
+		switch *o.Source {
+		case LogSourceApp, LogSourceHeroku:
+		default:
+			return fmt.Errorf("heroku: invalid log source %q, must be %q or %q", *o.Source, LogSourceApp, LogSourceHeroku)
+		}
+	}
+	if o.Dyno != nil && !dynoNameRe.MatchString(*o.Dyno) {
+		return fmt.Errorf("heroku: invalid dyno %q, must be a process type or dyno name such as web or web.1", *o.Dyno)
+	}
+	if o.Lines != nil && (*o.Lines < 1 || *o.Lines > MaxLogLines) {
+		if o.Tail != nil && *o.Tail {
+			return fmt.Errorf("heroku: lines must be between 1 and %d when tailing; use a smaller backlog and let the tail stream the rest", MaxLogLines)
+		}
+		return fmt.Errorf("heroku: lines must be between 1 and %d", MaxLogLines)
+	}
+	return nil
+}
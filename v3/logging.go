@@ -0,0 +1,184 @@
+package heroku
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// LogLevel selects what Service logs to its Logger.
+type LogLevel int
+
+const (
+	// LogWarnings logs warnings only, such as API version mismatches.
+	LogWarnings LogLevel = iota
+
+	// LogRequests also logs a line per request, with its method, path,
+	// status code and duration.
+	LogRequests
+
+	// LogBodies also logs the headers and bodies of API requests and
+	// responses, masked by the Redactor of the Service. Response bodies
+	// are read at once to be logged.
+	LogBodies
+)
+
+// A Redactor masks secrets in the headers and bodies that Service logs.
+//
+// It only applies to what is logged at LogBodies. Transport.Debug dumps
+// and the body snippets kept in Error and DecodeError follow a fixed
+// policy instead, which extending a Redactor does not change: credential
+// headers such as Authorization and Heroku-Two-Factor-Code are masked,
+// and bodies of resources that hold secrets, such as config vars, dynos,
+// OAuth resources and SSL endpoints, are left out entirely.
+type Redactor struct {
+	// Headers are the names of headers whose values are masked.
+	Headers []string
+
+	// Field reports whether the value of a field of a JSON body sent to
+	// or received from path must be masked. Fields are masked at any
+	// depth.
+	Field func(path, field string) bool
+}
+
+// redacted replaces masked values.
+const redacted = "[redacted]"
+
+// DefaultRedactor masks credentials in headers, all config vars, and the
+// fields holding private keys, passwords, secrets, tokens and dyno attach
+// URLs. It may be copied and extended:
+//
+//	r := *heroku.DefaultRedactor
+//	r.Headers = append(r.Headers, "X-Internal-Token")
+//	s.Redactor = &r
+var DefaultRedactor = &Redactor{
+	Headers: []string{
+		"Authorization",
+		"Cookie",
+		"Heroku-Two-Factor-Code",
+		"Proxy-Authorization",
+		"Set-Cookie",
+	},
+	Field: func(path, field string) bool {
+		if strings.Contains(path, "/config-vars") {
+			return true
+		}
+		switch field {
+		case "access_token", "attach_url", "new_password", "password",
+			"private_key", "refresh_token", "secret", "token":
+			return true
+		}
+		return false
+	},
+}
+
+// Header returns a copy of h with the values of r.Headers masked.
+func (r *Redactor) Header(h http.Header) http.Header {
+	h = h.Clone()
+	for _, k := range r.Headers {
+		if _, ok := h[http.CanonicalHeaderKey(k)]; ok {
+			h.Set(k, redacted)
+		}
+	}
+	return h
+}
+
+// Body returns body, sent to or received from path, with the fields
+// selected by r.Field masked. Bodies that are not JSON are left out.
+func (r *Redactor) Body(path string, body []byte) []byte {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return body
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return []byte("[non-JSON body]")
+	}
+	b, err := json.Marshal(r.mask(path, v))
+	if err != nil {
+		return []byte("[non-JSON body]")
+	}
+	return b
+}
+
+func (r *Redactor) mask(path string, v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range t {
+			if r.Field != nil && r.Field(path, k) {
+				t[k] = redacted
+			} else {
+				t[k] = r.mask(path, fv)
+			}
+		}
+	case []interface{}:
+		for i, ev := range t {
+			t[i] = r.mask(path, ev)
+		}
+	}
+	return v
+}
+
+// redactor returns the Redactor of s, or DefaultRedactor.
+func (s *Service) redactor() *Redactor {
+	if s.Redactor != nil {
+		return s.Redactor
+	}
+	return DefaultRedactor
+}
+
+// logRequest logs the headers and body of req at LogBodies.
+func (s *Service) logRequest(req *http.Request) {
+	if s.Logger == nil || s.LogLevel < LogBodies || req.URL.Host != apiHost {
+		return
+	}
+	r := s.redactor()
+	var body []byte
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(rc)
+			rc.Close()
+		}
+	}
+	s.Logger.Printf("heroku: > %s %s %v %s", req.Method, req.URL.Path, r.Header(req.Header), r.Body(req.URL.Path, body))
+}
+
+// logResponse logs the headers and body of resp at LogBodies. The body
+// is read and replaced by a reader of its contents.
+func (s *Service) logResponse(req *http.Request, resp *http.Response) {
+	if s.Logger == nil || s.LogLevel < LogBodies || req.URL.Host != apiHost {
+		return
+	}
+	r := s.redactor()
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		// Let decoding report the error.
+		resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err}))
+	}
+	s.Logger.Printf("heroku: < %s %s %v %s", resp.Status, req.URL.Path, r.Header(resp.Header), r.Body(req.URL.Path, body))
+}
+
+// logDone logs a line for a request sent at start at LogRequests.
+func (s *Service) logDone(req *http.Request, start time.Time, resp *http.Response, err error) {
+	if s.Logger == nil || s.LogLevel < LogRequests {
+		return
+	}
+	path := req.URL.Path
+	if req.URL.Host != apiHost {
+		path = req.URL.Host + path
+	}
+	if err != nil {
+		s.Logger.Printf("heroku: %s %s failed after %v: %v", req.Method, path, time.Since(start), err)
+		return
+	}
+	s.Logger.Printf("heroku: %s %s %d %v", req.Method, path, resp.StatusCode, time.Since(start))
+}
+
+// errReader is a reader failing with err.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
@@ -0,0 +1,97 @@
+package heroku
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestRedactorBody(t *testing.T) {
+	tests := []struct {
+		path, body string
+		secrets    []string
+	}{
+		{"/apps/example/config-vars", `{"DATABASE_URL":"postgres://hunter2","PLAIN":"value"}`, []string{"hunter2", "value"}},
+		{"/apps/example/dynos", `{"name":"run.1","attach_url":"rendezvous://host:5000/secret1"}`, []string{"secret1"}},
+		{"/oauth/authorizations", `{"access_token":{"token":"t0k3n"},"refresh_token":{"token":"r3fr3sh"}}`, []string{"t0k3n", "r3fr3sh"}},
+		{"/apps/example/ssl-endpoints", `[{"ssl_cert":{"private_key":"-----BEGIN KEY"}}]`, []string{"BEGIN KEY"}},
+		{"/oauth/clients", `{"nested":{"deeper":[{"secret":"s3cr3t"}]}}`, []string{"s3cr3t"}},
+		{"/account", `{"password":"p4ss","new_password":"n3w"}`, []string{"p4ss", "n3w"}},
+	}
+	for _, tt := range tests {
+		got := string(DefaultRedactor.Body(tt.path, []byte(tt.body)))
+		for _, secret := range tt.secrets {
+			if strings.Contains(got, secret) {
+				t.Errorf("%s: %s holds %q", tt.path, got, secret)
+			}
+		}
+		if !strings.Contains(got, redacted) {
+			t.Errorf("%s: %s has nothing masked", tt.path, got)
+		}
+	}
+
+	if got := string(DefaultRedactor.Body("/apps", []byte(`{"name":"example"}`))); got != `{"name":"example"}` {
+		t.Errorf("body without secrets = %s, want it unchanged", got)
+	}
+	if got := string(DefaultRedactor.Body("/apps", []byte("token=secret"))); strings.Contains(got, "secret") {
+		t.Errorf("non-JSON body = %s, want it left out", got)
+	}
+}
+
+func TestRedactorHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer t0k3n")
+	h.Set("Heroku-Two-Factor-Code", "123456")
+	h.Set("Cookie", "session=s3ss10n")
+	h.Set("Request-Id", "01")
+	got := DefaultRedactor.Header(h)
+	for _, k := range []string{"Authorization", "Heroku-Two-Factor-Code", "Cookie"} {
+		if v := got.Get(k); v != redacted {
+			t.Errorf("%s = %q, want it masked", k, v)
+		}
+	}
+	if v := got.Get("Request-Id"); v != "01" {
+		t.Errorf("Request-Id = %q, want it kept", v)
+	}
+	if h.Get("Authorization") != "Bearer t0k3n" {
+		t.Error("Header modified its argument")
+	}
+}
+
+func TestLogBodiesRedacted(t *testing.T) {
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=s3ss10n")
+		switch r.URL.Path {
+		case "/apps/example/config-vars":
+			w.Write([]byte(`{"DATABASE_URL":"postgres://hunter2"}`))
+		default:
+			w.Write([]byte(`{"id":"01","access_token":{"token":"t0k3n"},"client":{"secret":"s3cr3t"}}`))
+		}
+	}))
+	var buf bytes.Buffer
+	s.Logger = log.New(&buf, "", 0)
+	s.LogLevel = LogBodies
+	s.OnConfigVarChange = func(ConfigVarAudit) {}
+
+	ctx := WithTwoFactorCode(context.Background(), "123456")
+	if err := s.DoWithContext(ctx, nil, "PATCH", "/apps/example/config-vars", map[string]string{"DATABASE_URL": "postgres://hunter2"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Get(nil, "/oauth/authorizations/01", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := buf.String()
+	for _, secret := range []string{"hunter2", "123456", "t0k3n", "s3cr3t", "s3ss10n"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("logs hold %q:\n%s", secret, logs)
+		}
+	}
+	if !strings.Contains(logs, "DATABASE_URL") {
+		t.Errorf("logs do not hold the names of config vars:\n%s", logs)
+	}
+}
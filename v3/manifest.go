@@ -0,0 +1,158 @@
+package heroku
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A Manifest is the content of an app.json file, which describes how to
+// set up an app with AppSetupCreate. See
+// https://devcenter.heroku.com/articles/app-json-schema.
+type Manifest struct {
+	Addons       []ManifestAddon              `json:"addons,omitempty"`       // add-ons to provision on the app
+	Buildpacks   []ManifestBuildpack          `json:"buildpacks,omitempty"`   // buildpacks to build the app with
+	Description  string                       `json:"description,omitempty"`  // description of the app
+	Env          map[string]ManifestEnv       `json:"env,omitempty"`          // config vars to set on the app
+	Environments map[string]json.RawMessage   `json:"environments,omitempty"` // overrides for specific environments, e.g. test
+	Formation    map[string]ManifestFormation `json:"formation,omitempty"`    // process types to scale after the build
+	Image        string                       `json:"image,omitempty"`        // Docker image to use for the app
+	Keywords     []string                     `json:"keywords,omitempty"`     // keywords describing the app
+	Logo         string                       `json:"logo,omitempty"`         // URL of the app's logo
+	Name         string                       `json:"name,omitempty"`         // name of the app
+	Repository   string                       `json:"repository,omitempty"`   // location of the app's source code
+	Scripts      map[string]string            `json:"scripts,omitempty"`      // scripts run at specific times, e.g. postdeploy
+	Stack        string                       `json:"stack,omitempty"`        // stack to run the app on
+	SuccessURL   string                       `json:"success_url,omitempty"`  // URL to redirect to after setup
+	Website      string                       `json:"website,omitempty"`      // app's website
+}
+
+// ManifestAddon is an add-on of a Manifest. In app.json it is either a
+// plan name or an object.
+type ManifestAddon struct {
+	As      string                 `json:"as,omitempty"`      // name of the attachment
+	Options map[string]interface{} `json:"options,omitempty"` // provisioning options
+	Plan    string                 `json:"plan"`              // add-on plan, e.g. "heroku-postgresql:hobby-dev"
+}
+
+func (a *ManifestAddon) UnmarshalJSON(b []byte) error {
+	var plan string
+	if err := json.Unmarshal(b, &plan); err == nil {
+		*a = ManifestAddon{Plan: plan}
+		return nil
+	}
+	type addon ManifestAddon
+	return json.Unmarshal(b, (*addon)(a))
+}
+
+// ManifestBuildpack is a buildpack of a Manifest.
+type ManifestBuildpack struct {
+	URL string `json:"url"` // buildpack name or URL
+}
+
+// ManifestEnv is a config var of a Manifest. In app.json it is either a
+// value or an object.
+type ManifestEnv struct {
+	Description string `json:"description,omitempty"` // human readable description
+	Generator   string `json:"generator,omitempty"`   // generator of the value, only "secret" is supported
+	Required    *bool  `json:"required,omitempty"`    // whether a value must be given; defaults to true
+	Value       string `json:"value,omitempty"`       // default value
+}
+
+func (e *ManifestEnv) UnmarshalJSON(b []byte) error {
+	var value string
+	if err := json.Unmarshal(b, &value); err == nil {
+		*e = ManifestEnv{Value: value}
+		return nil
+	}
+	type env ManifestEnv
+	return json.Unmarshal(b, (*env)(e))
+}
+
+// required reports whether a value must be provided for e on setup.
+func (e ManifestEnv) required() bool {
+	return (e.Required == nil || *e.Required) && e.Value == "" && e.Generator == ""
+}
+
+// ManifestFormation is a process type of a Manifest.
+type ManifestFormation struct {
+	Quantity int    `json:"quantity"`       // number of processes to run
+	Size     string `json:"size,omitempty"` // dyno size
+}
+
+// ParseManifest reads an app.json manifest from r. The manifest is not
+// validated, see Manifest.Validate.
+func ParseManifest(r io.Reader) (*Manifest, error) {
+	var m Manifest
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("heroku: parsing app.json: %v", err)
+	}
+	return &m, nil
+}
+
+var envNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Validate checks the manifest for errors the platform would report on
+// AppSetupCreate. All errors found are reported at once.
+func (m *Manifest) Validate() error {
+	var errs []string
+	for k, e := range m.Env {
+		if !envNameRe.MatchString(k) {
+			errs = append(errs, fmt.Sprintf("env: invalid name %q", k))
+		}
+		if g := e.Generator; g != "" && g != "secret" {
+			errs = append(errs, fmt.Sprintf("env: %s: unknown generator %q", k, g))
+		}
+	}
+	for i, a := range m.Addons {
+		if a.Plan == "" {
+			errs = append(errs, fmt.Sprintf("addons: #%d: missing plan", i))
+		}
+	}
+	for i, b := range m.Buildpacks {
+		if b.URL == "" {
+			errs = append(errs, fmt.Sprintf("buildpacks: #%d: missing url", i))
+		}
+	}
+	for t, f := range m.Formation {
+		if f.Quantity < 0 {
+			errs = append(errs, fmt.Sprintf("formation: %s: negative quantity", t))
+		}
+	}
+	for k := range m.Scripts {
+		switch k {
+		case "postdeploy", "pr-predestroy":
+		default:
+			errs = append(errs, fmt.Sprintf("scripts: unknown script %q", k))
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("heroku: invalid app.json: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// EnvOverrides checks that values provides every config var the
+// manifest requires and returns them in a form suitable for the env
+// overrides of AppSetupCreate.
+func (m *Manifest) EnvOverrides(values map[string]string) (*map[string]string, error) {
+	var missing []string
+	for k, e := range m.Env {
+		if _, ok := values[k]; !ok && e.required() {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("heroku: missing required config vars: %s", strings.Join(missing, ", "))
+	}
+	env := make(map[string]string, len(values))
+	for k, v := range values {
+		env[k] = v
+	}
+	return &env, nil
+}
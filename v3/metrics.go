@@ -0,0 +1,108 @@
+package heroku
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// endpoint is an endpoint of the API.
+type endpoint struct {
+	resource string // name of the resource in the schema, e.g. "app"
+	name     string // name of the method of Service, e.g. "AppInfo"
+	method   string // HTTP method, e.g. "GET"
+	template string // path template, e.g. "/apps/{app}"
+}
+
+// extraEndpoints are the endpoints of hand-written methods.
+var extraEndpoints = []endpoint{
+	{"account", "AccountRecoveryCodesCreate", "POST", "/account/recovery-codes"},
+	{"addon", "AddonSSO", "GET", "/apps/{app}/addons/{addon}/sso"},
+}
+
+// An Endpoint describes a method of Service calling a single endpoint of
+// the API, for tools discovering the capabilities of the package at run
+// time.
+type Endpoint struct {
+	Resource string // name of the resource in the schema, e.g. "app"
+	Name     string // name of the method of Service, e.g. "AppInfo"
+	Method   string // HTTP method, e.g. "GET"
+	Path     string // path template, e.g. "/apps/{app}"
+}
+
+// Endpoints returns the endpoints called by the methods of Service,
+// grouped by resource. Methods combining several requests, such as
+// Deploy or AppsByNames, are not included.
+func Endpoints() []Endpoint {
+	all := allEndpoints()
+	sort.SliceStable(all, func(i, j int) bool { return all[i].resource < all[j].resource })
+	list := make([]Endpoint, len(all))
+	for i, e := range all {
+		list[i] = Endpoint{Resource: e.resource, Name: e.name, Method: e.method, Path: e.template}
+	}
+	return list
+}
+
+// allEndpoints returns the generated and hand-written endpoints.
+func allEndpoints() []endpoint {
+	return append(endpoints[:len(endpoints):len(endpoints)], extraEndpoints...)
+}
+
+// unknownPathTemplate is the path template of requests to the API that
+// match no known endpoint, such as custom paths sent with Do.
+const unknownPathTemplate = "unknown"
+
+// pathTemplate returns the template of the endpoint matching the method
+// and path of a request, e.g. "/apps/{app}/dynos/{dyno}" for GET
+// /apps/example/dynos/web.1. Of the endpoints matching, the one with the
+// most literal segments is used, so that /organizations/apps matches
+// itself rather than /organizations/{organization}. unknownPathTemplate
+// is returned if no endpoint matches, as the path may hold identities.
+func pathTemplate(method, path string) string {
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	best, bestLiterals := unknownPathTemplate, -1
+	for _, e := range allEndpoints() {
+		if e.method != method {
+			continue
+		}
+		tsegs := strings.Split(strings.Trim(e.template, "/"), "/")
+		if len(tsegs) != len(segs) {
+			continue
+		}
+		literals := 0
+		for i, t := range tsegs {
+			if strings.HasPrefix(t, "{") {
+				continue
+			}
+			if t != segs[i] {
+				literals = -1
+				break
+			}
+			literals++
+		}
+		if literals > bestLiterals {
+			best, bestLiterals = e.template, literals
+		}
+	}
+	return best
+}
+
+// reportRequest calls the OnRequestComplete hook of s, if any, for req
+// sent at start.
+func (s *Service) reportRequest(req *http.Request, start time.Time, resp *http.Response, err error) {
+	if s.OnRequestComplete == nil {
+		return
+	}
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	} else if e, ok := asError(err); ok {
+		status = e.StatusCode
+	}
+	template := req.URL.Host
+	if req.URL.Host == apiHost {
+		template = pathTemplate(req.Method, req.URL.Path)
+	}
+	s.OnRequestComplete(req.Method, template, status, time.Since(start), err)
+}
@@ -0,0 +1,20 @@
+package heroku
+
+import "testing"
+
+func TestPathTemplate(t *testing.T) {
+	tests := []struct {
+		method, path, want string
+	}{
+		{"GET", "/apps/example/dynos/web.1", "/apps/{app}/dynos/{dyno}"},
+		{"GET", "/organizations/apps", "/organizations/apps"},
+		{"DELETE", "/apps/example/addons/heroku-postgresql", "/apps/{app}/addons/{addon}"},
+		{"GET", "/apps/example/unknown-resource/secret-id", "unknown"},
+		{"PUT", "/apps/example", "unknown"},
+	}
+	for _, tt := range tests {
+		if got := pathTemplate(tt.method, tt.path); got != tt.want {
+			t.Errorf("pathTemplate(%s, %s) = %q, want %q", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package heroku
+
+// OAuthAuthorizationListByClient lists the OAuth authorizations obtained
+// through the given OAuth client, identified by id or name. Filtering is
+// done on the client.
+func (s *Service) OAuthAuthorizationListByClient(clientIdentity string, lr *ListRange) ([]*OAuthAuthorization, error) {
+	authorizations, err := s.OAuthAuthorizationList(lr)
+	if err != nil {
+		return nil, err
+	}
+	var filtered []*OAuthAuthorization
+	for _, a := range authorizations {
+		if a.Client != nil && (a.Client.ID == clientIdentity || a.Client.Name == clientIdentity) {
+			filtered = append(filtered, a)
+		}
+	}
+	return filtered, nil
+}
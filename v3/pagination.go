@@ -0,0 +1,99 @@
+package heroku
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// A Cursor points at the page following a partial list response. It is
+// opaque: use Next to obtain the ListRange for the following request.
+//
+// List endpoints answer with 206 Partial Content when more items are
+// available and 200 OK for the last page; both are successful.
+type Cursor struct {
+	next         string
+	contentRange string
+	acceptRanges []string
+}
+
+// newCursor builds a Cursor from the range headers of resp.
+func newCursor(resp *http.Response) *Cursor {
+	c := &Cursor{
+		next:         resp.Header.Get("Next-Range"),
+		contentRange: resp.Header.Get("Content-Range"),
+	}
+	for _, field := range strings.Split(resp.Header.Get("Accept-Ranges"), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			c.acceptRanges = append(c.acceptRanges, field)
+		}
+	}
+	return c
+}
+
+// Next returns the range to request the following page with, or nil
+// if the last page has been reached.
+func (c *Cursor) Next() *ListRange {
+	if c == nil || c.next == "" {
+		return nil
+	}
+	return &ListRange{raw: c.next}
+}
+
+// AcceptRanges returns the fields the server accepts as ListRange.Field
+// for this list, as advertised in its Accept-Ranges header.
+func (c *Cursor) AcceptRanges() []string {
+	if c == nil {
+		return nil
+	}
+	return c.acceptRanges
+}
+
+// ContentRange returns the Content-Range header of the page, which
+// describes the range of items it holds, e.g. "id 01..ff; max=200".
+func (c *Cursor) ContentRange() string {
+	if c == nil {
+		return ""
+	}
+	return c.contentRange
+}
+
+// Bounds returns the range field and the first and last values of the
+// items the page holds, as given by its Content-Range header, e.g. "id",
+// "01" and "ff" for "id 01..ff; max=200". ok is false if the header is
+// missing or malformed.
+func (c *Cursor) Bounds() (field, first, last string, ok bool) {
+	spec := c.rangeSpec()
+	i := strings.IndexByte(spec, ' ')
+	if i < 0 {
+		return "", "", "", false
+	}
+	field, rng := spec[:i], strings.TrimSpace(spec[i+1:])
+	if j := strings.LastIndexByte(rng, '/'); j >= 0 {
+		rng = rng[:j]
+	}
+	first, last, ok = strings.Cut(rng, "..")
+	return field, first, last, ok
+}
+
+// Total returns the total number of items of the list, if the server
+// gives it in the Content-Range header of the page, e.g. "id 01..ff/243".
+// The API does not currently do so for any list, in which case ok is
+// false; the number of items is then only known once the last page,
+// whose Next is nil, has been read.
+func (c *Cursor) Total() (n int, ok bool) {
+	spec := c.rangeSpec()
+	j := strings.LastIndexByte(spec, '/')
+	if j < 0 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(spec[j+1:])
+	return n, err == nil
+}
+
+// rangeSpec returns the Content-Range header of the page without its
+// parameters, e.g. "id 01..ff" for "id 01..ff; max=200".
+func (c *Cursor) rangeSpec() string {
+	spec, _, _ := strings.Cut(c.ContentRange(), ";")
+	return strings.TrimSpace(spec)
+}
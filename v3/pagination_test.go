@@ -0,0 +1,167 @@
+package heroku
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestPartialContentList(t *testing.T) {
+	var ranges []string
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Range") == "" {
+			w.Header().Set("Content-Range", "id 01..01; max=1")
+			w.Header().Set("Next-Range", "]01..; max=1")
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte(`[{"id":"01","name":"first"}]`))
+			return
+		}
+		w.Header().Set("Content-Range", "id 02..02; max=1")
+		w.Write([]byte(`[{"id":"02","name":"second"}]`))
+	}))
+
+	apps, cursor, err := s.AppListPage(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 1 || apps[0].Name != "first" {
+		t.Fatalf("first page = %v, want app first", apps)
+	}
+	if got := cursor.ContentRange(); got != "id 01..01; max=1" {
+		t.Errorf("ContentRange = %q", got)
+	}
+	next := cursor.Next()
+	if next == nil {
+		t.Fatal("Next = nil after a 206 response with Next-Range")
+	}
+
+	apps, cursor, err = s.AppListPage(next)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 1 || apps[0].Name != "second" {
+		t.Fatalf("second page = %v, want app second", apps)
+	}
+	if ranges[1] != "]01..; max=1" {
+		t.Errorf("second request Range = %q, want the Next-Range of the first page", ranges[1])
+	}
+	if cursor.Next() != nil {
+		t.Error("Next != nil on the last page")
+	}
+}
+
+func TestListAllFollowsNextRange(t *testing.T) {
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Range") == "" {
+			w.Header().Set("Next-Range", "]01..")
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte(`[{"name":"first"}]`))
+			return
+		}
+		w.Write([]byte(`[{"name":"second"}]`))
+	}))
+	apps, err := s.AppListAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != 2 {
+		t.Errorf("AppListAll returned %d apps, want 2", len(apps))
+	}
+}
+
+func TestDefaultListRange(t *testing.T) {
+	ranges := make(map[string]string)
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges[r.URL.Path] = r.Header.Get("Range")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/apps" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	s.DefaultListRange = &ListRange{Max: 1000, Descending: true}
+	if _, err := s.AppList(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AppInfo("example"); err != nil {
+		t.Fatal(err)
+	}
+	if got := ranges["/apps"]; got != "..; max=1000, order=desc" {
+		t.Errorf("AppList Range = %q, want the default range", got)
+	}
+
+	delete(ranges, "/apps")
+	if err := s.AppListEach(nil, func(*App) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if got := ranges["/apps"]; got != "..; max=1000, order=desc" {
+		t.Errorf("AppListEach Range = %q, want the default range", got)
+	}
+	if got := ranges["/apps/example"]; got != "" {
+		t.Errorf("AppInfo Range = %q, want none", got)
+	}
+}
+
+func TestListRangeSetHeader(t *testing.T) {
+	tests := []struct {
+		lr   ListRange
+		want string
+	}{
+		{ListRange{}, ".."},
+		{ListRange{Field: "name", FirstID: "a", LastID: "z"}, "name a..z"},
+		{ListRange{Max: 10}, "..; max=10"},
+		{ListRange{Descending: true}, "..; order=desc"},
+		{ListRange{Field: "id", Max: 10, Descending: true}, "id ..; max=10, order=desc"},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest("GET", "/apps", nil)
+		tt.lr.SetHeader(req)
+		if got := req.Header.Get("Range"); got != tt.want {
+			t.Errorf("%+v: Range = %q, want %q", tt.lr, got, tt.want)
+		}
+	}
+}
+
+// pagedHandler serves the JSON arrays of pages to GET requests for path,
+// one page per request following Next-Range, and records the other
+// requests.
+type pagedHandler struct {
+	path  string
+	pages []string
+
+	mu    sync.Mutex
+	other []string
+}
+
+func (h *pagedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method != "GET" || r.URL.Path != h.path {
+		h.mu.Lock()
+		h.other = append(h.other, r.Method+" "+r.URL.Path)
+		h.mu.Unlock()
+		w.Write([]byte(`{}`))
+		return
+	}
+	page := 0
+	if rng := r.Header.Get("Range"); rng != "" {
+		page = int(rng[len(rng)-1] - '0')
+	}
+	if page+1 < len(h.pages) {
+		w.Header().Set("Next-Range", "page "+string(rune('0'+page+1)))
+		w.WriteHeader(http.StatusPartialContent)
+	}
+	w.Write([]byte(h.pages[page]))
+}
+
+// requests returns the sorted requests other than listing pages.
+func (h *pagedHandler) requests() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	sort.Strings(h.other)
+	return h.other
+}
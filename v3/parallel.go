@@ -0,0 +1,53 @@
+package heroku
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultParallelism is the number of requests ParallelGet sends at the
+// same time when no limit is given.
+const DefaultParallelism = 4
+
+// A GetRequest is a GET request sent by ParallelGet. The response is
+// decoded into V, as with Get.
+type GetRequest struct {
+	Path  string
+	V     interface{}
+	Range *ListRange
+}
+
+// ParallelGet sends the GET requests reqs concurrently, at most limit at
+// a time, or DefaultParallelism if limit is not positive, and waits for
+// all of them to complete. Requests are aborted once ctx is canceled.
+//
+// The returned errors are in the order of reqs, with a nil error for each
+// request that succeeded:
+//
+//	var app heroku.App
+//	var config map[string]string
+//	var dynos []*heroku.Dyno
+//	errs := s.ParallelGet(ctx, 0,
+//		heroku.GetRequest{Path: "/apps/example", V: &app},
+//		heroku.GetRequest{Path: "/apps/example/config-vars", V: &config},
+//		heroku.GetRequest{Path: "/apps/example/dynos", V: &dynos},
+//	)
+func (s *Service) ParallelGet(ctx context.Context, limit int, reqs ...GetRequest) []error {
+	if limit <= 0 {
+		limit = DefaultParallelism
+	}
+	errs := make([]error, len(reqs))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, r := range reqs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, r GetRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			_, errs[i] = s.do(ctx, r.V, "GET", r.Path, nil, r.Range)
+		}(i, r)
+	}
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,40 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Compliance regimes a plan may be certified for.
+const (
+	ComplianceHIPAA = "HIPAA"
+	CompliancePCI   = "PCI"
+)
+
+// PlanListCompliant lists the plans of an add-on service that comply
+// with the given regime, e.g. ComplianceHIPAA, compared
+// case-insensitively. Filtering is done on the client, over all pages of
+// the plan list; lr, if set, is the range of the first page, e.g. to set
+// the page size with Max.
+func (s *Service) PlanListCompliant(addonServiceIdentity, tag string, lr *ListRange) ([]*Plan, error) {
+	var compliant []*Plan
+	for {
+		var plans []*Plan
+		resp, err := s.do(context.Background(), &plans, "GET", fmt.Sprintf("/addon-services/%v/plans", pathEscape(addonServiceIdentity)), nil, lr)
+		if err != nil {
+			return nil, err
+		}
+		for _, plan := range plans {
+			for _, c := range plan.Compliance {
+				if strings.EqualFold(c, tag) {
+					compliant = append(compliant, plan)
+					break
+				}
+			}
+		}
+		if lr = newCursor(resp).Next(); lr == nil {
+			return compliant, nil
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package heroku
+
+import "testing"
+
+func TestPlanListCompliant(t *testing.T) {
+	s := newTestService(t, &pagedHandler{path: "/addon-services/heroku-postgresql/plans", pages: []string{
+		`[{"name":"hobby-dev","compliance":null},{"name":"standard-0","compliance":["hipaa"]}]`,
+		`[{"name":"premium-0","compliance":["PCI","HIPAA"]},{"name":"private-0","compliance":["PCI"]}]`,
+	}})
+	plans, err := s.PlanListCompliant("heroku-postgresql", ComplianceHIPAA, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, p := range plans {
+		names = append(names, p.Name)
+	}
+	if len(names) != 2 || names[0] != "standard-0" || names[1] != "premium-0" {
+		t.Errorf("compliant plans = %v, want standard-0 and premium-0", names)
+	}
+}
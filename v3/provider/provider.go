@@ -0,0 +1,104 @@
+// Package provider contains types for implementing the provider side of
+// the Heroku Add-on Partner API.
+//
+// Heroku calls into an add-on provider to provision, deprovision and
+// change the plan of resources. Each request is authenticated using HTTP
+// basic auth with the add-on manifest id as username and the manifest
+// password as password:
+//
+//	func provision(w http.ResponseWriter, r *http.Request) {
+//	    if err := provider.VerifyProviderRequest(r, id, password); err != nil {
+//	        http.Error(w, err.Error(), http.StatusUnauthorized)
+//	        return
+//	    }
+//	    var req provider.ProvisionRequest
+//	    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+//	        http.Error(w, err.Error(), http.StatusBadRequest)
+//	        return
+//	    }
+//	    ...
+//	}
+package provider
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// ErrUnauthorized is returned by VerifyProviderRequest when a request
+// does not carry the expected credentials.
+var ErrUnauthorized = errors.New("provider: invalid or missing credentials")
+
+// A provision request is sent by Heroku to create a new resource for an
+// app. It is sent as a POST to the provider's base URL.
+type ProvisionRequest struct {
+	CallbackURL  string            `json:"callback_url"`  // URL to use when updating the resource asynchronously
+	HerokuID     string            `json:"heroku_id"`     // identifier of the app the resource is provisioned for
+	LogInputURL  *string           `json:"log_input_url"` // URL to which the resource may send logs
+	LogplexToken *string           `json:"logplex_token"` // deprecated token for sending logs
+	Options      map[string]string `json:"options"`       // custom provisioning options passed by the user
+	Plan         string            `json:"plan"`          // name of the plan to provision
+	Region       string            `json:"region"`        // region the app runs in
+	UUID         string            `json:"uuid"`          // unique identifier of the add-on
+}
+
+// A provision response is returned to Heroku once a resource has been
+// created.
+type ProvisionResponse struct {
+	Config  map[string]string `json:"config,omitempty"`  // config vars to set on the app
+	ID      string            `json:"id"`                // unique identifier of the resource with its provider
+	Message *string           `json:"message,omitempty"` // message displayed to the user
+}
+
+// A plan change request is sent by Heroku as a PUT to the resource URL
+// when a user upgrades or downgrades their plan.
+type PlanChangeRequest struct {
+	HerokuID string `json:"heroku_id"` // identifier of the app the resource is provisioned for
+	Plan     string `json:"plan"`      // name of the new plan
+	UUID     string `json:"uuid"`      // unique identifier of the add-on
+}
+
+// A plan change response is returned to Heroku once the plan of a
+// resource has been changed.
+type PlanChangeResponse struct {
+	Config  map[string]string `json:"config,omitempty"`  // config vars to update on the app
+	Message *string           `json:"message,omitempty"` // message displayed to the user
+}
+
+// A deprovision request is sent by Heroku as a DELETE to the resource
+// URL and carries no body. The resource id is the last path segment of
+// the request URL; use ParseDeprovisionRequest to obtain it.
+type DeprovisionRequest struct {
+	ID string // unique identifier of the resource with its provider
+}
+
+// ParseDeprovisionRequest returns the deprovision request sent as r,
+// whose resource id is the last segment of its path, e.g. "01" for
+// DELETE /heroku/resources/01.
+func ParseDeprovisionRequest(r *http.Request) (*DeprovisionRequest, error) {
+	id := path.Base(strings.TrimSuffix(r.URL.Path, "/"))
+	if id == "" || id == "." || id == "/" {
+		return nil, fmt.Errorf("provider: no resource id in path %q", r.URL.Path)
+	}
+	return &DeprovisionRequest{ID: id}, nil
+}
+
+// VerifyProviderRequest checks that r carries the HTTP basic auth
+// credentials of the add-on manifest. The comparison is done in constant
+// time.
+func VerifyProviderRequest(r *http.Request, id, password string) error {
+	u, p, ok := r.BasicAuth()
+	if !ok {
+		return ErrUnauthorized
+	}
+	uok := subtle.ConstantTimeCompare([]byte(u), []byte(id)) == 1
+	pok := subtle.ConstantTimeCompare([]byte(p), []byte(password)) == 1
+	if !uok || !pok {
+		return ErrUnauthorized
+	}
+	return nil
+}
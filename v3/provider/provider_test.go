@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVerifyProviderRequest(t *testing.T) {
+	tests := []struct {
+		name           string
+		user, password string
+		auth           bool
+		want           error
+	}{
+		{"valid", "myaddon", "s3cr3t", true, nil},
+		{"bad username", "other", "s3cr3t", true, ErrUnauthorized},
+		{"bad password", "myaddon", "wrong", true, ErrUnauthorized},
+		{"empty password", "myaddon", "", true, ErrUnauthorized},
+		{"missing header", "", "", false, ErrUnauthorized},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("POST", "/heroku/resources", nil)
+		if tt.auth {
+			r.SetBasicAuth(tt.user, tt.password)
+		}
+		if err := VerifyProviderRequest(r, "myaddon", "s3cr3t"); err != tt.want {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	r := httptest.NewRequest("POST", "/heroku/resources", nil)
+	r.Header.Set("Authorization", "Bearer s3cr3t")
+	if err := VerifyProviderRequest(r, "myaddon", "s3cr3t"); err != ErrUnauthorized {
+		t.Errorf("bearer token: err = %v, want %v", err, ErrUnauthorized)
+	}
+}
+
+func TestParseDeprovisionRequest(t *testing.T) {
+	tests := []struct {
+		path string
+		id   string
+	}{
+		{"/heroku/resources/01", "01"},
+		{"/heroku/resources/01/", "01"},
+		{"/heroku/resources/a%20b", "a b"},
+		{"/", ""},
+	}
+	for _, tt := range tests {
+		req, err := ParseDeprovisionRequest(httptest.NewRequest(http.MethodDelete, tt.path, nil))
+		switch {
+		case tt.id == "" && err == nil:
+			t.Errorf("%s: got id %q, want an error", tt.path, req.ID)
+		case tt.id != "" && err != nil:
+			t.Errorf("%s: %v", tt.path, err)
+		case tt.id != "" && req.ID != tt.id:
+			t.Errorf("%s: id = %q, want %q", tt.path, req.ID, tt.id)
+		}
+	}
+}
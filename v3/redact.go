@@ -0,0 +1,71 @@
+package heroku
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// sensitivePaths are path fragments of resources whose requests or
+// responses carry secrets such as config vars, private keys or tokens.
+// Debug dumps and error snippets leave out their bodies entirely, as
+// these are raw or truncated and cannot be masked field by field like
+// the bodies logged with a Redactor; hence "/dynos" here, where
+// DefaultRedactor masks attach_url only.
+var sensitivePaths = []string{
+	"/config-vars",
+	"/dynos", // attach URLs of dynos hold a rendezvous secret
+	"/oauth/",
+	"/ssl-endpoints",
+}
+
+// sensitivePath reports whether requests or responses for path may
+// contain secrets and must therefore never be echoed in errors or logs.
+func sensitivePath(path string) bool {
+	for _, p := range sensitivePaths {
+		if strings.Contains(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// sensitiveResponse reports whether resp is for a sensitive path.
+func sensitiveResponse(resp *http.Response) bool {
+	return resp.Request != nil && sensitivePath(resp.Request.URL.Path)
+}
+
+// redactURL strips the query string from u, which holds the signature
+// of signed URLs such as slug blob URLs.
+func redactURL(u string) string {
+	if i := strings.IndexByte(u, '?'); i >= 0 {
+		return u[:i] + "?[redacted]"
+	}
+	return u
+}
+
+// redactError removes secrets from the URL of transport errors.
+func redactError(err error) error {
+	if ue, ok := err.(*url.Error); ok {
+		return &url.Error{Op: ue.Op, URL: redactURL(ue.URL), Err: ue.Err}
+	}
+	return err
+}
+
+var (
+	dumpAuthRe  = regexp.MustCompile(`(?mi)^(Authorization|Cookie|Heroku-Two-Factor-Code|Proxy-Authorization|Set-Cookie): [^\r\n]*`)
+	dumpQueryRe = regexp.MustCompile(`^(\S+ [^?\s]*)\?\S*`)
+)
+
+// redactDump masks credentials and query strings in an HTTP request or
+// response dump.
+func redactDump(dump []byte) []byte {
+	dump = dumpAuthRe.ReplaceAll(dump, []byte("$1: [redacted]"))
+	if i := bytes.IndexByte(dump, '\n'); i >= 0 {
+		line := dumpQueryRe.ReplaceAll(dump[:i], []byte("$1?[redacted]"))
+		dump = append(append([]byte(nil), line...), dump[i:]...)
+	}
+	return dump
+}
@@ -0,0 +1,73 @@
+package heroku
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// roundTripFunc is an http.RoundTripper calling itself.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestDebugDumpRedacted(t *testing.T) {
+	var buf bytes.Buffer
+	defer func(w io.Writer) { debugOutput = w }(debugOutput)
+	debugOutput = &buf
+
+	tr := &Transport{
+		Username: "user@example.com",
+		Password: "p4ssw0rd",
+		Debug:    true,
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     "200 OK",
+				Proto:      "HTTP/1.1",
+				ProtoMajor: 1,
+				ProtoMinor: 1,
+				Header: http.Header{
+					"Content-Type": {"application/json"},
+					"Set-Cookie":   {"session=s3ss10n"},
+				},
+				Body:    io.NopCloser(strings.NewReader(`{"DATABASE_URL":"postgres://hunter2"}`)),
+				Request: req,
+			}, nil
+		}),
+	}
+	req, err := http.NewRequest("PATCH", DefaultAPIURL+"/apps/example/config-vars?sig=s1gn4ture", strings.NewReader(`{"SECRET_KEY":"k3y"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Heroku-Two-Factor-Code", "123456")
+	req.Header.Set("Cookie", "session=c00k13")
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	dump := buf.String()
+	for _, secret := range []string{
+		"p4ssw0rd",
+		"dXNlckBleGFtcGxlLmNvbTpwNHNzdzByZA", // base64 of the credentials
+		"123456",
+		"c00k13",
+		"s3ss10n",
+		"s1gn4ture",
+		"k3y",
+		"hunter2",
+	} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("dump holds %q:\n%s", secret, dump)
+		}
+	}
+	for _, want := range []string{"Authorization: [redacted]", "Heroku-Two-Factor-Code: [redacted]", "Set-Cookie: [redacted]"} {
+		if !strings.Contains(dump, want) {
+			t.Errorf("dump does not hold %q:\n%s", want, dump)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package heroku
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// maxRedirects is the number of redirects followed by CheckRedirect, as
+// by default in net/http.
+const maxRedirects = 10
+
+// credentialHeaders are request headers that must not be sent to other
+// hosts than the one they were meant for.
+var credentialHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Heroku-Two-Factor-Code",
+	"Idempotency-Key",
+}
+
+// CheckRedirect is a redirect policy for http.Client that removes
+// credentials, such as the Authorization and Heroku-Two-Factor-Code
+// headers, from requests redirected to another host than the original
+// request, e.g. when a blob URL redirects to signed storage. It is used
+// by Service for clients without a CheckRedirect of their own.
+//
+// A client given to NewService with its own CheckRedirect keeps it, and
+// with it loses this protection: credentials are then only removed as
+// net/http does, which keeps headers such as Heroku-Two-Factor-Code on
+// every redirect. Such a policy should call CheckRedirect itself.
+func CheckRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("heroku: stopped after %d redirects", maxRedirects)
+	}
+	if req.URL.Host != via[0].URL.Host {
+		for _, h := range credentialHeaders {
+			req.Header.Del(h)
+		}
+	}
+	return nil
+}
+
+// httpClient returns the client of s, with CheckRedirect as redirect
+// policy unless it has one.
+func (s *Service) httpClient() *http.Client {
+	if s.client.CheckRedirect != nil {
+		return s.client
+	}
+	c := *s.client
+	c.CheckRedirect = CheckRedirect
+	return &c
+}
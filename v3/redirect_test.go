@@ -0,0 +1,74 @@
+package heroku
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckRedirect(t *testing.T) {
+	var got http.Header
+	record := func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte("slug"))
+	}
+	other := httptest.NewServer(http.HandlerFunc(record))
+	defer other.Close()
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cross-host":
+			http.Redirect(w, r, other.URL+"/blob", http.StatusFound)
+		case "/same-host":
+			http.Redirect(w, r, "/blob", http.StatusFound)
+		default:
+			record(w, r)
+		}
+	}))
+	defer origin.Close()
+
+	s := NewService(&http.Client{})
+	tests := []struct {
+		path string
+		kept bool
+	}{
+		{"/cross-host", false},
+		{"/same-host", true},
+	}
+	for _, tt := range tests {
+		got = nil
+		req, err := s.newBlobRequest("GET", origin.URL+tt.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.SetBasicAuth("", "token")
+		req.Header.Set("Heroku-Two-Factor-Code", "123456")
+		if _, err := s.send(nil, req); err != nil {
+			t.Fatalf("%s: %v", tt.path, err)
+		}
+		if got == nil {
+			t.Fatalf("%s: redirect target not reached", tt.path)
+		}
+		for _, h := range []string{"Authorization", "Heroku-Two-Factor-Code"} {
+			if sent := got.Get(h) != ""; sent != tt.kept {
+				t.Errorf("%s: %s sent to redirect target = %v, want %v", tt.path, h, sent, tt.kept)
+			}
+		}
+	}
+}
+
+func TestCheckRedirectLimit(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, srv.URL+"/again", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	s := NewService(&http.Client{})
+	req, err := s.newBlobRequest("GET", srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.send(nil, req); err == nil {
+		t.Error("endless redirects: no error")
+	}
+}
@@ -0,0 +1,78 @@
+package heroku
+
+// checkRegion returns an error if region is set but does not match the
+// id or name of an existing region. It is a no-op unless
+// s.ValidateRegionAndStack is set.
+func (s *Service) checkRegion(region *string) error {
+	if !s.ValidateRegionAndStack || region == nil {
+		return nil
+	}
+	_, err := s.regionCache.lookup("region", *region, func() ([]identity, error) {
+		regions, err := s.RegionList(nil)
+		ids := make([]identity, len(regions))
+		for i, r := range regions {
+			ids[i] = identity{r.ID, r.Name}
+		}
+		return ids, err
+	})
+	return err
+}
+
+// checkStack returns an error if stack is set but does not match the id
+// or name of an existing stack. It is a no-op unless
+// s.ValidateRegionAndStack is set.
+func (s *Service) checkStack(stack *string) error {
+	if !s.ValidateRegionAndStack || stack == nil {
+		return nil
+	}
+	_, err := s.stackCache.lookup("stack", *stack, func() ([]identity, error) {
+		stacks, err := s.StackList(nil)
+		ids := make([]identity, len(stacks))
+		for i, st := range stacks {
+			ids[i] = identity{st.ID, st.Name}
+		}
+		return ids, err
+	})
+	return err
+}
+
+// RegionListPrivateCapable lists the regions in which Private Spaces can
+// be created.
+func (s *Service) RegionListPrivateCapable() ([]*Region, error) {
+	regions, err := s.RegionList(nil)
+	if err != nil {
+		return nil, err
+	}
+	var capable []*Region
+	for _, r := range regions {
+		if r.PrivateCapable {
+			capable = append(capable, r)
+		}
+	}
+	return capable, nil
+}
+
+// RegionListForAddonService lists the regions in which the add-on
+// service with the given id or name is available, joining RegionList with
+// the region capabilities of the service.
+func (s *Service) RegionListForAddonService(addonServiceIdentity string) ([]*Region, error) {
+	capabilities, err := s.AddonRegionCapabilityListByAddonService(addonServiceIdentity, nil)
+	if err != nil {
+		return nil, err
+	}
+	available := make(map[string]bool, len(capabilities))
+	for _, c := range capabilities {
+		available[c.Region.ID] = true
+	}
+	regions, err := s.RegionList(nil)
+	if err != nil {
+		return nil, err
+	}
+	var supported []*Region
+	for _, r := range regions {
+		if available[r.ID] {
+			supported = append(supported, r)
+		}
+	}
+	return supported, nil
+}
@@ -0,0 +1,46 @@
+package heroku
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestValidateRegionAndStack(t *testing.T) {
+	var created bool
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/regions":
+			w.Write([]byte(`[{"id":"01","name":"us"},{"id":"02","name":"eu"}]`))
+		case "/stacks":
+			w.Write([]byte(`[{"id":"03","name":"cedar-14"}]`))
+		case "/apps":
+			created = true
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"name":"example"}`))
+		}
+	}))
+	s.ValidateRegionAndStack = true
+
+	tests := []struct {
+		region, stack string
+		err           string
+	}{
+		{"eu", "cedar-14", ""},
+		{"mars", "cedar-14", `unknown region "mars"`},
+		{"us", "bamboo", `unknown stack "bamboo"`},
+	}
+	for _, tt := range tests {
+		created = false
+		_, err := s.AppCreate(AppCreateOpts{Region: String(tt.region), Stack: String(tt.stack)})
+		switch {
+		case tt.err == "" && err != nil:
+			t.Errorf("%s/%s: %v", tt.region, tt.stack, err)
+		case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+			t.Errorf("%s/%s: err = %v, want %s", tt.region, tt.stack, err, tt.err)
+		case created != (tt.err == ""):
+			t.Errorf("%s/%s: app created = %v", tt.region, tt.stack, created)
+		}
+	}
+}
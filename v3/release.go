@@ -0,0 +1,8 @@
+package heroku
+
+// ReleaseListRecent returns the n most recent releases of the app, newest
+// first. Releases are ordered by version, the only range field for which
+// a descending list is meaningful.
+func (s *Service) ReleaseListRecent(appIdentity string, n int) ([]*Release, error) {
+	return s.ReleaseList(appIdentity, &ListRange{Field: "version", Max: n, Descending: true})
+}
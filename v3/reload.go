@@ -0,0 +1,41 @@
+package heroku
+
+// AppReload refreshes app in place with its current state. app is left
+// untouched if an error is returned.
+func (s *Service) AppReload(app *App) error {
+	fresh, err := s.AppInfo(app.ID)
+	if err == nil {
+		*app = *fresh
+	}
+	return err
+}
+
+// AddonReload refreshes addon of the app in place with its current
+// state. addon is left untouched if an error is returned.
+func (s *Service) AddonReload(appIdentity string, addon *Addon) error {
+	fresh, err := s.AddonInfo(appIdentity, addon.ID)
+	if err == nil {
+		*addon = *fresh
+	}
+	return err
+}
+
+// BuildReload refreshes build of the app in place with its current
+// state. build is left untouched if an error is returned.
+func (s *Service) BuildReload(appIdentity string, build *Build) error {
+	fresh, err := s.BuildInfo(appIdentity, build.ID)
+	if err == nil {
+		*build = *fresh
+	}
+	return err
+}
+
+// DynoReload refreshes dyno of the app in place with its current state.
+// dyno is left untouched if an error is returned.
+func (s *Service) DynoReload(appIdentity string, dyno *Dyno) error {
+	fresh, err := s.DynoInfo(appIdentity, dyno.ID)
+	if err == nil {
+		*dyno = *fresh
+	}
+	return err
+}
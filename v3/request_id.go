@@ -0,0 +1,26 @@
+package heroku
+
+import (
+	"context"
+	"net/http"
+)
+
+type requestIDContextKey struct{}
+
+// WithRequestID returns a copy of ctx that makes requests sent with it,
+// e.g. using DoWithContext, carry id in their Request-Id header instead
+// of a random one. The API logs the id with the request and echoes it
+// in the Request-Id response header, which is available on responses
+// returned by DoResponse and in Error.RequestID, so that requests can be
+// correlated with logs and traces of the caller.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// setRequestID sets the Request-Id header of req from its context, if
+// any.
+func setRequestID(req *http.Request) {
+	if id, ok := req.Context().Value(requestIDContextKey{}).(string); ok && id != "" {
+		req.Header.Set("Request-Id", id)
+	}
+}
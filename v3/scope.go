@@ -0,0 +1,51 @@
+package heroku
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OAuth scopes. See https://devcenter.heroku.com/articles/oauth#scopes.
+const (
+	ScopeGlobal         = "global"          // full access
+	ScopeIdentity       = "identity"        // read-only access to the account
+	ScopeRead           = "read"            // read access to non-protected resources
+	ScopeWrite          = "write"           // write access to non-protected resources
+	ScopeReadProtected  = "read-protected"  // read access to all resources, including config vars
+	ScopeWriteProtected = "write-protected" // write access to all resources, including config vars
+)
+
+// checkScope returns an error if a request with the given method and
+// path is obviously outside of scopes. It is advisory only: the API
+// remains the authority on what a token may do.
+func checkScope(scopes []string, method, path string) error {
+	if len(scopes) == 0 {
+		return nil
+	}
+	has := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		has[scope] = true
+	}
+	if has[ScopeGlobal] {
+		return nil
+	}
+
+	read := method == "GET" || method == "HEAD"
+	protected := strings.Contains(path, "/config-vars")
+	var allowed bool
+	switch {
+	case read && protected:
+		allowed = has[ScopeReadProtected] || has[ScopeWriteProtected]
+	case read:
+		allowed = has[ScopeRead] || has[ScopeWrite] || has[ScopeReadProtected] || has[ScopeWriteProtected] ||
+			(has[ScopeIdentity] && path == "/account")
+	case protected:
+		allowed = has[ScopeWriteProtected]
+	default:
+		allowed = has[ScopeWrite] || has[ScopeWriteProtected]
+	}
+	if !allowed {
+		return fmt.Errorf("heroku: %s %s is not allowed with scope %s", method, path, strings.Join(scopes, ","))
+	}
+	return nil
+}
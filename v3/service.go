@@ -0,0 +1,494 @@
+// Package heroku is a client for the Heroku Platform API.
+//
+// To be able to interact with this API, you have to
+// create a new service:
+//
+//	s := heroku.NewService(nil)
+//
+// The Service struct has all the methods you need
+// to interact with heroku API. They are generated from
+// schema.json into heroku.go by gen.go.
+//
+// Optional properties of request bodies are pointers: a nil
+// pointer leaves the property out of the request, while a
+// pointer to a zero value sends it. Use Bool, Int, Float64
+// and String to set them:
+//
+//	s.AppUpdate("example", heroku.AppUpdateOpts{
+//		Maintenance: heroku.Bool(false),
+//	})
+package heroku
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+)
+
+//go:generate go run gen.go -o heroku.go schema.json
+
+const (
+	Version          = "v3"
+	DefaultAPIURL    = "https://api.heroku.com"
+	DefaultUserAgent = "heroku/" + Version + " (" + runtime.GOOS + "; " + runtime.GOARCH + ")"
+)
+
+// Self identifies the account of the authenticated user and may be used
+// wherever an account id or email is expected in a path, such as
+// /users/~/apps.
+const Self = "~"
+
+// pathEscape escapes an identity for use as a path segment. Unlike
+// url.PathEscape, it also escapes "+", which some servers decode as a
+// space, so that emails such as foo+bar@example.com identify accounts.
+func pathEscape(s string) string {
+	return strings.Replace(url.PathEscape(s), "+", "%2B", -1)
+}
+
+// Service represents your API.
+type Service struct {
+	client *http.Client
+
+	// Scopes, if set, are the OAuth scopes of the token used by client.
+	// Requests that are obviously out of these scopes fail before being
+	// sent.
+	Scopes []string
+
+	// Coalesce enables sharing a single round trip between identical GET
+	// requests that are in flight at the same time. The shared round
+	// trip is not canceled with the context of any caller, and is
+	// limited by Timeout, or a minute if Timeout is not set; each caller
+	// still returns as soon as its own context is done.
+	// Requests with caller-specific headers, such as those set with
+	// WithRequestID or WithTwoFactorCode, are always sent on their own.
+	Coalesce bool
+	flights  flightGroup
+
+	// DynoSizeFromFormation makes DynoCreate use the dyno size of the
+	// app's web process type when no size is given, like `heroku run`
+	// does, instead of the platform default.
+	DynoSizeFromFormation bool
+
+	// ValidateRegionAndStack makes AppCreate and OrganizationAppCreate
+	// check that the requested region and stack exist, against RegionList
+	// and StackList, before creating the app. The lists are fetched once
+	// and cached. Whether the region supports the stack, or the space of
+	// the app, is left to the API, which exposes no such capabilities.
+	ValidateRegionAndStack bool
+	regionCache            identityCache
+	stackCache             identityCache
+
+	dynoSizeCache identityCache // dyno sizes fetched by DynoSizeName
+
+	// DefaultListRange is used for lists requested without a range,
+	// e.g. {Max: 1000, Descending: true}, by list methods such as
+	// AppList or by Do with a pointer to a slice. A range passed to a
+	// call takes precedence.
+	DefaultListRange *ListRange
+
+	// DefaultRegion and DefaultStack are used by AppCreate and
+	// OrganizationAppCreate when no region or stack is given.
+	DefaultRegion string
+	DefaultStack  string
+
+	// DefaultOrganization, if set, is the organization that
+	// OrganizationAppCreate creates apps in and OrganizationAppList lists
+	// apps of, instead of the default organization of the account.
+	DefaultOrganization string
+
+	// EncodeJSON, if set, is a custom encoder used instead of
+	// json.Encoder with its default settings to encode request bodies,
+	// e.g. to disable HTML escaping. It only changes the encoding: bodies
+	// are still encoded in full into memory before the request is sent,
+	// so that requests keep a Content-Length and can be sent again on
+	// redirects.
+	EncodeJSON func(w io.Writer, v interface{}) error
+
+	// Timeout, if set, limits the time API requests may take, including
+	// reading the response. It does not apply to requests whose context
+	// already has a deadline, so that a call can be given more or less
+	// time with DoWithContext and context.WithTimeout, nor to file
+	// transfers such as SlugUpload, nor to the total time spent by
+	// methods that wait, such as DynoRestartAndWait, which take their
+	// own timeout.
+	Timeout time.Duration
+
+	// DeployGuard, if set, is called before requests that create a
+	// release or a build, such as ReleaseCreate, BuildCreate or Deploy,
+	// or that scale an app, such as FormationUpdate. Unless it returns
+	// true, the request is not sent and fails with a DeployBlockedError
+	// holding the returned reason, e.g. to only deploy within approved
+	// maintenance windows.
+	DeployGuard func() (ok bool, reason string)
+
+	// OnRequestComplete, if set, is called after each request with its
+	// method, path template, status code, duration and error, e.g. to
+	// record metrics. The path template identifies the endpoint without
+	// identities, such as /apps/{app}/dynos/{dyno}, so that it can be
+	// used as a metric label; it is "unknown" for API requests matching
+	// no endpoint of the package, such as custom paths sent with Do, and
+	// the host for requests to other hosts than the API, such as slug
+	// transfers. The status code is 0 if no response was received.
+	OnRequestComplete func(method, pathTemplate string, status int, dur time.Duration, err error)
+
+	// OnConfigVarChange, if set, is called before each request changing
+	// config vars, such as ConfigVarUpdate, with the names of the config
+	// vars and the actor and reason given with WithAudit, e.g. to keep an
+	// audit trail. If it is not set, changes are logged to Logger instead.
+	// Values of config vars are never passed nor logged.
+	OnConfigVarChange func(ConfigVarAudit)
+
+	// Logger, if set, receives warnings about responses, such as
+	// responses served in an API version other than Version, and config
+	// var changes unless OnConfigVarChange is set.
+	Logger *log.Logger
+
+	// LogLevel selects what is logged to Logger besides warnings.
+	LogLevel LogLevel
+
+	// Redactor masks secrets in the headers and bodies logged at
+	// LogBodies. It will default to DefaultRedactor if nil.
+	Redactor *Redactor
+}
+
+// NewService creates a Service using the given, if none is provided
+// it uses http.DefaultClient.
+func NewService(c *http.Client) *Service {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return &Service{
+		client: c,
+	}
+}
+
+// NewRequest generates an HTTP request, but does not perform the request.
+// The path is relative to DefaultAPIURL unless it is an absolute URL.
+//
+// String bodies and bodies encoded as JSON are buffered, so that the
+// request has a GetBody func and can be sent again, e.g. on redirects or
+// retries. So can requests with a *bytes.Buffer, *bytes.Reader or
+// *strings.Reader body. Other io.Reader bodies, such as files, are read
+// as they are sent and only once: the request has no GetBody and cannot
+// be replayed, so uploads rewind their reader themselves to retry.
+func (s *Service) NewRequest(method, path string, body interface{}) (*http.Request, error) {
+	var ctype string
+	var rbody io.Reader
+	switch t := body.(type) {
+	case nil:
+	case string:
+		rbody = bytes.NewBufferString(t)
+	case io.Reader:
+		rbody = t
+	default:
+		v := reflect.ValueOf(body)
+		if !v.IsValid() {
+			break
+		}
+		if v.Type().Kind() == reflect.Ptr {
+			v = reflect.Indirect(v)
+			if !v.IsValid() {
+				break
+			}
+		}
+		encode := s.EncodeJSON
+		if encode == nil {
+			encode = defaultEncodeJSON
+		}
+		var buf bytes.Buffer
+		if err := encode(&buf, body); err != nil {
+			return nil, err
+		}
+		rbody = &buf
+		ctype = "application/json"
+	}
+	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		path = DefaultAPIURL + path
+	}
+	req, err := http.NewRequest(method, path, rbody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", DefaultUserAgent)
+	if ctype != "" {
+		req.Header.Set("Content-Type", ctype)
+	}
+	return req, nil
+}
+
+// newBlobRequest is like NewRequest but for requests transferring files,
+// such as slugs or build output, to or from hosts other than the API,
+// which accept any type of response.
+func (s *Service) newBlobRequest(method, u string, body io.Reader) (*http.Request, error) {
+	req, err := s.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "*/*")
+	return req, nil
+}
+
+// defaultEncodeJSON is the default encoder of request bodies.
+func defaultEncodeJSON(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// Do sends a request and decodes the response into v. v may be a
+// *json.RawMessage to keep the raw JSON of a resource, including fields
+// unknown to this package, e.g. to store or forward it. If v is an
+// io.Writer, the response body is copied to it as is, whatever its
+// content type. Error responses are never decoded into v but returned as
+// an Error.
+func (s *Service) Do(v interface{}, method, path string, body interface{}, lr *ListRange) error {
+	_, err := s.DoResponse(v, method, path, body, lr)
+	return err
+}
+
+// DoResponse is like Do but also returns the response, whose body has
+// already been consumed, to give access to headers such as Request-Id,
+// Content-Range or RateLimit-Remaining.
+func (s *Service) DoResponse(v interface{}, method, path string, body interface{}, lr *ListRange) (*http.Response, error) {
+	return s.do(context.Background(), v, method, path, body, lr)
+}
+
+// DoWithContext is like Do but the request is bound to ctx, so that it
+// is aborted once ctx is canceled or its deadline is exceeded.
+func (s *Service) DoWithContext(ctx context.Context, v interface{}, method, path string, body interface{}, lr *ListRange) error {
+	_, err := s.do(ctx, v, method, path, body, lr)
+	return err
+}
+
+// do sends a request, decodes the response into v and returns the
+// response with its body already consumed.
+func (s *Service) do(ctx context.Context, v interface{}, method, path string, body interface{}, lr *ListRange) (*http.Response, error) {
+	req, err := s.NewRequest(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if lr == nil && method == "GET" && req.URL.Host == apiHost && isList(v) {
+		lr = s.DefaultListRange
+	}
+	if lr != nil {
+		lr.SetHeader(req)
+	}
+	return s.send(v, req)
+}
+
+// isList reports whether v, as passed to Do, receives a list.
+func isList(v interface{}) bool {
+	switch v.(type) {
+	case *json.RawMessage:
+		return false
+	case listDecodeFunc:
+		return true
+	}
+	t := reflect.TypeOf(v)
+	return t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Slice
+}
+
+// send performs req, decodes the response into v and returns the
+// response with its body already consumed.
+func (s *Service) send(v interface{}, req *http.Request) (*http.Response, error) {
+	if req.URL.Host == apiHost {
+		if _, ok := req.Context().Deadline(); !ok && s.Timeout > 0 {
+			ctx, cancel := context.WithTimeout(req.Context(), s.Timeout)
+			defer cancel()
+			req = req.WithContext(ctx)
+		}
+		if err := checkScope(s.Scopes, req.Method, req.URL.Path); err != nil {
+			return nil, err
+		}
+		if err := checkDeployGuard(s.DeployGuard, req.Method, req.URL.Path); err != nil {
+			return nil, err
+		}
+		setIdempotencyKey(req)
+		setTwoFactorCode(req)
+		setRequestID(req)
+		s.auditConfigVars(req)
+	}
+	start := time.Now()
+	resp, err := s.exchange(v, req)
+	s.reportRequest(req, start, resp, err)
+	s.logDone(req, start, resp, err)
+	return resp, err
+}
+
+// exchange performs req and decodes the response into v, sharing the
+// round trip with identical requests if s.Coalesce is set.
+func (s *Service) exchange(v interface{}, req *http.Request) (*http.Response, error) {
+	if s.Coalesce && coalescable(req) {
+		return s.sendCoalesced(v, req)
+	}
+	resp, err := s.roundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return resp, decodeResponse(v, resp)
+}
+
+// roundTrip performs req and checks the response status. The caller
+// must close the response body if no error is returned.
+func (s *Service) roundTrip(req *http.Request) (*http.Response, error) {
+	s.logRequest(req)
+	resp, err := s.httpClient().Do(req)
+	if err != nil {
+		if e, ok := asError(err); ok {
+			return nil, e
+		}
+		return nil, redactError(err)
+	}
+	if err = checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if req.URL.Host == apiHost {
+		s.checkAPIVersion(req, resp)
+	}
+	s.logResponse(req, resp)
+	return resp, nil
+}
+
+// decodeResponse decodes the body of resp into v.
+func decodeResponse(v interface{}, resp *http.Response) error {
+	var err error
+	switch t := v.(type) {
+	case nil:
+	case decodeFunc:
+		err = t(resp.Body)
+	case listDecodeFunc:
+		err = t(resp.Body)
+	case io.Writer:
+		_, err = io.Copy(t, resp.Body)
+	default:
+		snippet := &snippetWriter{max: snippetSize}
+		err = json.NewDecoder(io.TeeReader(resp.Body, snippet)).Decode(v)
+		if err == io.EOF {
+			// Some endpoints succeed without a body (or with only
+			// whitespace); leave v untouched in that case.
+			err = nil
+		}
+		if err != nil {
+			err = newDecodeError(resp, snippet.buf, err)
+		}
+	}
+	return err
+}
+
+// Get sends a GET request and decodes the response into v.
+func (s *Service) Get(v interface{}, path string, lr *ListRange) error {
+	return s.Do(v, "GET", path, nil, lr)
+}
+
+// Patch sends a Path request and decodes the response into v.
+func (s *Service) Patch(v interface{}, path string, body interface{}) error {
+	return s.Do(v, "PATCH", path, body, nil)
+}
+
+// Post sends a POST request and decodes the response into v.
+func (s *Service) Post(v interface{}, path string, body interface{}) error {
+	return s.Do(v, "POST", path, body, nil)
+}
+
+// Put sends a PUT request and decodes the response into v.
+func (s *Service) Put(v interface{}, path string, body interface{}) error {
+	return s.Do(v, "PUT", path, body, nil)
+}
+
+// Delete sends a DELETE request.
+func (s *Service) Delete(path string) error {
+	return s.Do(nil, "DELETE", path, nil, nil)
+}
+
+// DeleteResource sends a DELETE request and decodes the response, which
+// for most resources is their final state, into v:
+//
+//	var addon heroku.Addon
+//	err := s.DeleteResource(&addon, "/apps/example/addons/heroku-postgresql")
+func (s *Service) DeleteResource(v interface{}, path string) error {
+	return s.Do(v, "DELETE", path, nil, nil)
+}
+
+// ListRange describes a range.
+type ListRange struct {
+	Field      string
+	Max        int
+	Descending bool
+	FirstID    string
+	LastID     string
+
+	raw string // verbatim Next-Range value returned by the server
+}
+
+// SetHeader set headers on the given Request.
+func (lr *ListRange) SetHeader(req *http.Request) {
+	if lr.raw != "" {
+		req.Header.Set("Range", lr.raw)
+		return
+	}
+	var hdrval string
+	if lr.Field != "" {
+		hdrval += lr.Field + " "
+	}
+	hdrval += lr.FirstID + ".." + lr.LastID
+	var params []string
+	if lr.Max != 0 {
+		params = append(params, fmt.Sprintf("max=%d", lr.Max))
+	}
+	if lr.Descending {
+		params = append(params, "order=desc")
+	}
+	if len(params) > 0 {
+		hdrval += "; " + strings.Join(params, ", ")
+	}
+	req.Header.Set("Range", hdrval)
+	return
+}
+
+// Bool allocates a new bool value returns a pointer to it.
+func Bool(v bool) *bool {
+	p := new(bool)
+	*p = v
+	return p
+}
+
+// Int allocates a new int value returns a pointer to it.
+func Int(v int) *int {
+	p := new(int)
+	*p = v
+	return p
+}
+
+// Float64 allocates a new float64 value returns a pointer to it.
+func Float64(v float64) *float64 {
+	p := new(float64)
+	*p = v
+	return p
+}
+
+// String allocates a new string value returns a pointer to it.
+func String(v string) *string {
+	p := new(string)
+	*p = v
+	return p
+}
+
+// Strings allocates a new string slice holding v and returns a pointer
+// to it, e.g. for OAuthAuthorizationCreateOpts.Scope. Strings() points
+// to an empty slice.
+func Strings(v ...string) *[]string {
+	p := new([]string)
+	*p = append([]string{}, v...)
+	return p
+}
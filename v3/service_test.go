@@ -0,0 +1,163 @@
+package heroku
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// apiTransport sends requests for the API host to a test server, so that
+// they are handled as API requests by Service.
+type apiTransport struct {
+	target *url.URL
+}
+
+func (t apiTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	if r.URL.Host == apiHost {
+		r.URL.Scheme = t.target.Scheme
+		r.URL.Host = t.target.Host
+	}
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// newTestService returns a Service whose API requests are served by
+// handler.
+func newTestService(t *testing.T, handler http.Handler) *Service {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewService(&http.Client{Transport: apiTransport{u}})
+}
+
+func TestEmptySuccessfulResponses(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+	}{
+		{http.StatusNoContent, ""},
+		{http.StatusCreated, ""},
+		{http.StatusCreated, " \n"},
+		{http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		}))
+		app, err := s.AppCreate(AppCreateOpts{})
+		if err != nil {
+			t.Errorf("%d %q: %v", tt.status, tt.body, err)
+			continue
+		}
+		if app.ID != "" {
+			t.Errorf("%d %q: decoded app %q, want zero value", tt.status, tt.body, app.ID)
+		}
+	}
+}
+
+func TestDecodeRawMessage(t *testing.T) {
+	const app = `{"id":"01","name":"example","unknown_field":{"kept":true}}`
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/apps/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"id":"not_found","message":"Couldn't find that app."}`))
+			return
+		}
+		w.Write([]byte(app))
+	}))
+
+	var raw json.RawMessage
+	if err := s.Get(&raw, "/apps/example", nil); err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != app {
+		t.Errorf("raw = %s, want %s", raw, app)
+	}
+
+	raw = nil
+	err := s.Get(&raw, "/apps/missing", nil)
+	if !IsNotFound(err) {
+		t.Errorf("err = %v, want a not found Error", err)
+	}
+	if raw != nil {
+		t.Errorf("raw = %s after an error response, want nil", raw)
+	}
+}
+
+func TestPathEscape(t *testing.T) {
+	var requestURI, path string
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURI, path = r.RequestURI, r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+
+	if _, err := s.CollaboratorInfo("app", "foo+bar@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(requestURI, "/collaborators/foo%2Bbar@example.com") {
+		t.Errorf("RequestURI = %q, want the identity with + escaped", requestURI)
+	}
+	if path != "/apps/app/collaborators/foo+bar@example.com" {
+		t.Errorf("decoded path = %q", path)
+	}
+
+	// Scopes and the deploy guard see the decoded path.
+	s.Scopes = []string{ScopeRead}
+	err := s.CollaboratorDelete("app", "foo+bar@example.com")
+	if err == nil || !strings.Contains(err.Error(), "DELETE /apps/app/collaborators/foo+bar@example.com") {
+		t.Errorf("CollaboratorDelete with read scope: err = %v, want a scope error for the decoded path", err)
+	}
+	s.Scopes = nil
+	s.DeployGuard = func() (bool, string) { return false, "frozen" }
+	_, err = s.ReleaseCreate("foo+bar", ReleaseCreateOpts{Slug: "01"})
+	if e, ok := err.(*DeployBlockedError); !ok || e.Path != "/apps/foo+bar/releases" {
+		t.Errorf("ReleaseCreate with a blocking guard: err = %v, want a DeployBlockedError for the decoded path", err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	s := newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	s.Timeout = 20 * time.Millisecond
+
+	// Methods preparing their own request, such as
+	// ConfigVarUpdateIfMatch, are limited too.
+	start := time.Now()
+	_, err := s.ConfigVarUpdateIfMatch("example", map[string]*string{"FOO": String("bar")}, `"etag"`)
+	if err == nil {
+		t.Fatal("ConfigVarUpdateIfMatch succeeded past Timeout")
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Errorf("ConfigVarUpdateIfMatch returned after %v, want about Timeout", d)
+	}
+	if _, err := s.AppInfo("example"); err == nil {
+		t.Error("AppInfo succeeded past Timeout")
+	}
+
+	// A deadline of the context takes precedence.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	s.DoWithContext(ctx, nil, "GET", "/apps/example", nil, nil)
+	if d := time.Since(start); d < 100*time.Millisecond {
+		t.Errorf("request with a context deadline returned after %v, want the deadline to apply", d)
+	}
+}
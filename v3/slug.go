@@ -0,0 +1,208 @@
+package heroku
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ProgressFunc is called during a transfer with the total number of
+// bytes transferred so far.
+type ProgressFunc func(n int64)
+
+type progressWriter struct {
+	w        io.Writer
+	n        int64
+	progress ProgressFunc
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.n += int64(n)
+	p.progress(p.n)
+	return n, err
+}
+
+type progressReader struct {
+	r        io.Reader
+	n        int64
+	progress ProgressFunc
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.n += int64(n)
+	p.progress(p.n)
+	return n, err
+}
+
+// SlugDownload writes the gzipped tarball of slug to w. The transfer is
+// aborted when ctx is canceled. If progress is not nil, it is called as
+// bytes are written to w.
+//
+// If slug has a SHA256 Checksum, the downloaded bytes are verified
+// against it and a *ChecksumError is returned on mismatch, after all of
+// them have been written to w. Slugs without a checksum, or with one of
+// another algorithm, are not verified.
+func (s *Service) SlugDownload(ctx context.Context, slug *Slug, w io.Writer, progress ProgressFunc) error {
+	req, err := s.newBlobRequest(strings.ToUpper(slug.Blob.Method), slug.Blob.URL, nil)
+	if err != nil {
+		return err
+	}
+	var want string
+	var h hash.Hash
+	if slug.Checksum != nil {
+		if want, h = checksumHash(*slug.Checksum); h != nil {
+			w = io.MultiWriter(w, h)
+		}
+	}
+	if progress != nil {
+		w = &progressWriter{w: w, progress: progress}
+	}
+	if _, err = s.send(w, req.WithContext(ctx)); err != nil || h == nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, want) {
+		return &ChecksumError{Want: *slug.Checksum, Got: "SHA256:" + got}
+	}
+	return nil
+}
+
+// ChecksumError is returned by SlugDownload when the downloaded bytes do
+// not match the checksum of the slug.
+type ChecksumError struct {
+	Want string // checksum of the slug, e.g. "SHA256:e3b0c442..."
+	Got  string // checksum of the downloaded bytes, in the same format
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("heroku: slug checksum mismatch: got %s, want %s", e.Got, e.Want)
+}
+
+// checksumHash returns the hex digest of a "SHA256:<hex>" checksum and a
+// hash to compute it with, or a nil hash if the algorithm of checksum is
+// not supported.
+func checksumHash(checksum string) (string, hash.Hash) {
+	algo, digest, ok := strings.Cut(checksum, ":")
+	if !ok || !strings.EqualFold(algo, "sha256") {
+		return "", nil
+	}
+	return digest, sha256.New()
+}
+
+// SlugUpload uploads size bytes read from r as the gzipped tarball of a
+// newly created slug. The transfer is aborted when ctx is canceled. If
+// progress is not nil, it is called as bytes are read from r.
+//
+// If r is an io.Seeker, such as an *os.File, uploads failing because of
+// network or storage errors are retried from the initial offset of r.
+func (s *Service) SlugUpload(ctx context.Context, slug *Slug, r io.Reader, size int64, progress ProgressFunc) error {
+	return s.upload(ctx, strings.ToUpper(slug.Blob.Method), slug.Blob.URL, r, size, progress)
+}
+
+// uploadAttempts is the number of times an upload is attempted when its
+// reader can be rewound.
+const uploadAttempts = 3
+
+// upload sends size bytes read from r to the blob store URL u, retrying
+// on transient errors if r is an io.Seeker.
+func (s *Service) upload(ctx context.Context, method, u string, r io.Reader, size int64, progress ProgressFunc) error {
+	seeker, _ := r.(io.Seeker)
+	var start int64
+	if seeker != nil {
+		var err error
+		if start, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			seeker = nil
+		}
+	}
+	for attempt := 1; ; attempt++ {
+		err := s.uploadOnce(ctx, method, u, r, size, progress)
+		if err == nil || seeker == nil || attempt == uploadAttempts || ctx.Err() != nil || !isTransient(err) {
+			return err
+		}
+		select {
+		case <-time.After(DefaultBackoff.Next(attempt)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Service) uploadOnce(ctx context.Context, method, u string, r io.Reader, size int64, progress ProgressFunc) error {
+	// Hide any Close method of r, so that http.Client does not close it
+	// and the upload can be retried.
+	r = struct{ io.Reader }{r}
+	if progress != nil {
+		r = &progressReader{r: r, progress: progress}
+	}
+	req, err := s.newBlobRequest(method, u, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	_, err = s.send(nil, req.WithContext(ctx))
+	return err
+}
+
+// SlugPromote copies the slug srcSlug of srcApp to dstApp and releases
+// it there. The slug keeps its process types, commit and buildpack
+// description.
+func (s *Service) SlugPromote(srcApp, srcSlug, dstApp string) (*Release, error) {
+	return s.SlugPromoteWithContext(context.Background(), srcApp, srcSlug, dstApp)
+}
+
+// SlugPromoteWithContext is like SlugPromote but its requests, including
+// the slug transfers, are bound to ctx, so that it is aborted once ctx is
+// canceled.
+func (s *Service) SlugPromoteWithContext(ctx context.Context, srcApp, srcSlug, dstApp string) (*Release, error) {
+	src := new(Slug)
+	if _, err := s.do(ctx, src, "GET", fmt.Sprintf("/apps/%v/slugs/%v", pathEscape(srcApp), pathEscape(srcSlug)), nil, nil); err != nil {
+		return nil, err
+	}
+
+	f, err := os.CreateTemp("", "heroku-slug-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err := s.SlugDownload(ctx, src, f, nil); err != nil {
+		return nil, err
+	}
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	dst := new(Slug)
+	if _, err := s.do(ctx, dst, "POST", fmt.Sprintf("/apps/%v/slugs", pathEscape(dstApp)), SlugCreateOpts{
+		BuildpackProvidedDescription: src.BuildpackProvidedDescription,
+		Commit:                       src.Commit,
+		ProcessTypes:                 src.ProcessTypes,
+	}, nil); err != nil {
+		return nil, err
+	}
+	if err := s.SlugUpload(ctx, dst, f, size, nil); err != nil {
+		return nil, err
+	}
+	var release Release
+	if _, err := s.do(ctx, &release, "POST", fmt.Sprintf("/apps/%v/releases", pathEscape(dstApp)), ReleaseCreateOpts{
+		Description: String("Promote " + srcApp + " slug " + src.ID),
+		Slug:        dst.ID,
+	}, nil); err != nil {
+		return nil, err
+	}
+	return &release, nil
+}
@@ -0,0 +1,41 @@
+package heroku
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSlugDownloadChecksum(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("slug"))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		checksum *string
+		ok       bool
+	}{
+		{nil, true},
+		{String("SHA256:cd03861f0ff8922a279f1eab771f91f6336df9e7dd9412355ec6a1a79c244a5e"), true},
+		{String("SHA256:0000000000000000000000000000000000000000000000000000000000000000"), false},
+		{String("MD5:00000000000000000000000000000000"), true}, // not verified
+	}
+	s := NewService(&http.Client{})
+	for _, tt := range tests {
+		var slug Slug
+		slug.Blob.Method = "get"
+		slug.Blob.URL = srv.URL
+		slug.Checksum = tt.checksum
+		var buf bytes.Buffer
+		err := s.SlugDownload(context.Background(), &slug, &buf, nil)
+		if _, mismatch := err.(*ChecksumError); tt.ok && err != nil || !tt.ok && !mismatch {
+			t.Errorf("checksum %v: err = %v", tt.checksum, err)
+		}
+		if buf.String() != "slug" {
+			t.Errorf("checksum %v: downloaded %q", tt.checksum, buf.String())
+		}
+	}
+}
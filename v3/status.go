@@ -0,0 +1,50 @@
+package heroku
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultStatusURL is the base URL of the Heroku status API.
+const DefaultStatusURL = "https://status.heroku.com/api/v4"
+
+// Status represents the current state of the Heroku platform as
+// reported by the status API.
+type Status struct {
+	Incidents []*Incident `json:"incidents"` // ongoing incidents
+	Scheduled []*Incident `json:"scheduled"` // scheduled maintenances
+	Status    []struct {
+		Status string `json:"status"` // status of the system: green, yellow or red
+		System string `json:"system"` // name of the system, e.g. Apps, Data or Tools
+	} `json:"status"` // status of each system of the platform
+}
+
+// An incident is a platform issue or maintenance reported on the Heroku
+// status site.
+type Incident struct {
+	CreatedAt time.Time `json:"created_at"` // when incident was created
+	FullURL   string    `json:"full_url"`   // URL of the incident on the status site
+	ID        int       `json:"id"`         // unique identifier of incident
+	Resolved  bool      `json:"resolved"`   // whether the incident has been resolved
+	Title     string    `json:"title"`      // title of incident
+	UpdatedAt time.Time `json:"updated_at"` // when incident was updated
+	Updates   []struct {
+		Contents   string    `json:"contents"`    // message of the update
+		CreatedAt  time.Time `json:"created_at"`  // when update was posted
+		ID         int       `json:"id"`          // unique identifier of update
+		UpdateType string    `json:"update_type"` // type of update, e.g. issue, monitoring or resolved
+	} `json:"updates"` // updates posted for the incident
+}
+
+// Current status of the platform.
+func (s *Service) StatusCurrent() (*Status, error) {
+	var status Status
+	return &status, s.Get(&status, fmt.Sprintf("%s/current-status", DefaultStatusURL), nil)
+}
+
+// List incidents reported on the status site. The status API does not
+// page its lists, so they take no ListRange.
+func (s *Service) StatusIncidentList() ([]*Incident, error) {
+	var incidentList []*Incident
+	return incidentList, s.Get(&incidentList, fmt.Sprintf("%s/incidents", DefaultStatusURL), nil)
+}
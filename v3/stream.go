@@ -0,0 +1,95 @@
+package heroku
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// decodeFunc can be passed as v to Do to consume the response body as a
+// stream instead of decoding it at once.
+type decodeFunc func(r io.Reader) error
+
+// listDecodeFunc is a decodeFunc consuming a list, to which
+// DefaultListRange applies.
+type listDecodeFunc decodeFunc
+
+// decodeEach returns a listDecodeFunc decoding a JSON array element by
+// element, calling fn with a decoder positioned on each element. It
+// stops at the first error returned by fn. An empty body is an empty
+// list.
+func decodeEach(fn func(dec *json.Decoder) error) listDecodeFunc {
+	return func(r io.Reader) error {
+		dec := json.NewDecoder(r)
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if tok != json.Delim('[') {
+			return fmt.Errorf("heroku: expected JSON array, got %v", tok)
+		}
+		for dec.More() {
+			if err := fn(dec); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+		return err
+	}
+}
+
+// AppListEach calls fn for each app of the requested range as the
+// response is decoded, so that only one app is held in memory at a
+// time. If fn returns an error, decoding stops and that error is
+// returned. Like AppList, it uses DefaultListRange if lr is nil.
+func (s *Service) AppListEach(lr *ListRange, fn func(*App) error) error {
+	return s.Do(decodeEach(func(dec *json.Decoder) error {
+		var app App
+		if err := dec.Decode(&app); err != nil {
+			return err
+		}
+		return fn(&app)
+	}), "GET", "/apps", nil, lr)
+}
+
+// OneOrMany returns a value to pass to Do to decode a response holding
+// either an array or a single object into the slice pointed to by list.
+// A single object is decoded as a slice of one element. It is meant for
+// endpoints whose response shape depends on the request; the methods of
+// Service expect the shape documented by the API, e.g. a single release
+// for ReleaseCreate and ReleaseRollback, and fail with a DecodeError on
+// a mismatch rather than leaving the result empty. An empty body leaves
+// the slice untouched, as an empty list.
+//
+//	var releases []*heroku.Release
+//	err := s.Get(heroku.OneOrMany(&releases), path, nil)
+func OneOrMany(list interface{}) interface{} {
+	return decodeFunc(func(r io.Reader) error {
+		var raw json.RawMessage
+		err := json.NewDecoder(r).Decode(&raw)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if b := bytes.TrimLeft(raw, " \t\r\n"); len(b) == 0 || b[0] != '{' {
+			return json.Unmarshal(raw, list)
+		}
+		v := reflect.ValueOf(list)
+		if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+			return fmt.Errorf("heroku: OneOrMany of non-pointer to slice %T", list)
+		}
+		elem := reflect.New(v.Elem().Type().Elem())
+		if err := json.Unmarshal(raw, elem.Interface()); err != nil {
+			return err
+		}
+		v.Elem().Set(reflect.Append(reflect.MakeSlice(v.Elem().Type(), 0, 1), elem.Elem()))
+		return nil
+	})
+}
@@ -0,0 +1,76 @@
+package heroku
+
+import (
+	"net/http"
+	"testing"
+)
+
+// jsonHandler serves body as a JSON response to every request.
+func jsonHandler(body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	})
+}
+
+func TestOneOrMany(t *testing.T) {
+	tests := []struct {
+		body string
+		want []string
+	}{
+		{`{"id":"01"}`, []string{"01"}},
+		{`[{"id":"01"},{"id":"02"}]`, []string{"01", "02"}},
+		{`[]`, []string{}},
+	}
+	for _, tt := range tests {
+		s := newTestService(t, jsonHandler(tt.body))
+		var releases []*Release
+		if err := s.Get(OneOrMany(&releases), "/apps/example/releases", nil); err != nil {
+			t.Errorf("%s: %v", tt.body, err)
+			continue
+		}
+		if releases == nil || len(releases) != len(tt.want) {
+			t.Errorf("%s: decoded %d releases, want %d", tt.body, len(releases), len(tt.want))
+			continue
+		}
+		for i, r := range releases {
+			if r.ID != tt.want[i] {
+				t.Errorf("%s: release %d has id %q, want %q", tt.body, i, r.ID, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestOneOrManyNonSlice(t *testing.T) {
+	s := newTestService(t, jsonHandler(`{"id":"01"}`))
+	var release Release
+	if err := s.Get(OneOrMany(&release), "/apps/example/releases/01", nil); err == nil {
+		t.Error("OneOrMany of a pointer to a struct: no error")
+	}
+}
+
+func TestReleaseCreateArrayResponse(t *testing.T) {
+	s := newTestService(t, jsonHandler(`[{"id":"01"},{"id":"02"}]`))
+	_, err := s.ReleaseCreate("example", ReleaseCreateOpts{Slug: "01"})
+	if _, ok := err.(*DecodeError); !ok {
+		t.Errorf("ReleaseCreate with an array response: err = %v, want a *DecodeError", err)
+	}
+}
+
+func TestStreamEmptyBody(t *testing.T) {
+	for _, body := range []string{"", " \n"} {
+		s := newTestService(t, jsonHandler(body))
+		var releases []*Release
+		if err := s.Get(OneOrMany(&releases), "/apps/example/releases", nil); err != nil {
+			t.Errorf("OneOrMany of %q: %v", body, err)
+		} else if len(releases) != 0 {
+			t.Errorf("OneOrMany of %q: decoded %d releases, want none", body, len(releases))
+		}
+		var n int
+		if err := s.AppListEach(nil, func(*App) error { n++; return nil }); err != nil {
+			t.Errorf("AppListEach of %q: %v", body, err)
+		} else if n != 0 {
+			t.Errorf("AppListEach of %q: called fn %d times, want none", body, n)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package heroku
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// String methods give a concise form of resources for logs. They only
+// show identifying fields and never secrets such as tokens or keys.
+
+func (a Account) String() string {
+	return fmt.Sprintf("Account(email=%s id=%s)", a.Email, a.ID)
+}
+
+func (a Addon) String() string {
+	return fmt.Sprintf("Addon(name=%s plan=%s id=%s)", a.Name, a.Plan.Name, a.ID)
+}
+
+func (a App) String() string {
+	return fmt.Sprintf("App(name=%s id=%s)", a.Name, a.ID)
+}
+
+func (b Build) String() string {
+	return fmt.Sprintf("Build(id=%s status=%s)", b.ID, b.Status)
+}
+
+func (c Collaborator) String() string {
+	return fmt.Sprintf("Collaborator(email=%s id=%s)", c.User.Email, c.ID)
+}
+
+func (d Domain) String() string {
+	return fmt.Sprintf("Domain(hostname=%s id=%s)", d.Hostname, d.ID)
+}
+
+func (d Dyno) String() string {
+	return fmt.Sprintf("Dyno(name=%s state=%s)", d.Name, d.State)
+}
+
+func (f Formation) String() string {
+	return fmt.Sprintf("Formation(type=%s quantity=%d size=%s)", f.Type, f.Quantity, f.Size)
+}
+
+func (k Key) String() string {
+	return fmt.Sprintf("Key(fingerprint=%s id=%s)", k.Fingerprint, k.ID)
+}
+
+func (l LogDrain) String() string {
+	// Drain URLs may carry credentials; only show where logs go.
+	host := ""
+	if u, err := url.Parse(l.URL); err == nil {
+		host = u.Host
+	}
+	return fmt.Sprintf("LogDrain(host=%s id=%s)", host, l.ID)
+}
+
+func (o Organization) String() string {
+	return fmt.Sprintf("Organization(name=%s role=%s)", o.Name, o.Role)
+}
+
+func (p Plan) String() string {
+	return fmt.Sprintf("Plan(name=%s id=%s)", p.Name, p.ID)
+}
+
+func (r Region) String() string {
+	return fmt.Sprintf("Region(name=%s id=%s)", r.Name, r.ID)
+}
+
+func (r Release) String() string {
+	return fmt.Sprintf("Release(version=%d id=%s)", r.Version, r.ID)
+}
+
+func (s Slug) String() string {
+	return fmt.Sprintf("Slug(id=%s)", s.ID)
+}
+
+func (s SSLEndpoint) String() string {
+	return fmt.Sprintf("SSLEndpoint(name=%s cname=%s)", s.Name, s.CName)
+}
+
+func (s Stack) String() string {
+	return fmt.Sprintf("Stack(name=%s state=%s)", s.Name, s.State)
+}
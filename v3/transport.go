@@ -1,19 +1,25 @@
 package heroku
 
 import (
-	"encoding/json"
-	"errors"
-	"fmt"
+	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"os"
 	"strings"
+	"time"
 
 	"code.google.com/p/go-uuid/uuid"
 )
 
-var DefaultTransport = &Transport{}
+var DefaultTransport = &Transport{Transport: NewHTTPTransport()}
+
+// debugOutput is where Transport.Debug dumps are written.
+var debugOutput io.Writer = os.Stderr
+
+// apiHost is the host of DefaultAPIURL.
+var apiHost = strings.TrimPrefix(DefaultAPIURL, "https://")
 
 var DefaultClient = &http.Client{
 	Transport: DefaultTransport,
@@ -30,7 +36,9 @@ type Transport struct {
 	// specified.
 	UserAgent string
 
-	// Debug mode can be used to dump the full request and response to stdout.
+	// Debug mode can be used to dump the full request and response to
+	// stderr. Credentials are masked and bodies of resources that hold
+	// secrets are left out; Service.Redactor does not apply to dumps.
 	Debug bool
 
 	// AdditionalHeaders are extra headers to add to each HTTP request sent by
@@ -42,6 +50,35 @@ type Transport struct {
 	Transport http.RoundTripper
 }
 
+// MaxIdleConnsPerHost is the number of idle connections to the API kept
+// by transports returned by NewHTTPTransport.
+const MaxIdleConnsPerHost = 32
+
+// NewHTTPTransport returns an HTTP transport tuned for the API, to be
+// used as the Transport of a Transport. DefaultTransport uses one.
+//
+// http.DefaultTransport keeps only 2 idle connections per host, so that
+// a client sending more concurrent requests to the API, e.g. with
+// ParallelGet or bulk operations, keeps opening new connections and
+// paying for TLS handshakes. The returned transport keeps up to
+// MaxIdleConnsPerHost connections, bounds the time spent connecting and
+// negotiates HTTP/2 when possible.
+func NewHTTPTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   MaxIdleConnsPerHost,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
 func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if t.Transport == nil {
 		t.Transport = http.DefaultTransport
@@ -55,20 +92,30 @@ func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 		req.Header.Set("User-Agent", t.UserAgent)
 	}
 
-	req.Header.Set("Accept", "application/vnd.heroku+json; version=3")
-	req.Header.Set("Request-Id", uuid.New())
-	req.SetBasicAuth(t.Username, t.Password)
-	for k, v := range t.AdditionalHeaders {
-		req.Header[k] = v
+	// Credentials and API specific headers are only sent to the API
+	// itself, never to other hosts such as the status API.
+	if req.URL.Host == apiHost {
+		// Keep variants of version 3 requested by the caller, such as
+		// version=3.add-ons-sso.
+		if !strings.HasPrefix(req.Header.Get("Accept"), "application/vnd.heroku+json") {
+			req.Header.Set("Accept", "application/vnd.heroku+json; version=3")
+		}
+		if req.Header.Get("Request-Id") == "" {
+			req.Header.Set("Request-Id", uuid.New())
+		}
+		req.SetBasicAuth(t.Username, t.Password)
+		for k, v := range t.AdditionalHeaders {
+			req.Header[k] = v
+		}
 	}
 
 	if t.Debug {
-		dump, err := httputil.DumpRequestOut(req, true)
+		dump, err := httputil.DumpRequestOut(req, !sensitivePath(req.URL.Path))
 		if err != nil {
 			log.Println(err)
 		} else {
-			os.Stderr.Write(dump)
-			os.Stderr.Write([]byte{'\n', '\n'})
+			debugOutput.Write(redactDump(dump))
+			debugOutput.Write([]byte{'\n', '\n'})
 		}
 	}
 
@@ -78,45 +125,25 @@ func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	}
 
 	if t.Debug {
-		dump, err := httputil.DumpResponse(resp, true)
+		dump, err := httputil.DumpResponse(resp, !sensitivePath(req.URL.Path))
 		if err != nil {
 			log.Println(err)
 		} else {
-			os.Stderr.Write(dump)
-			os.Stderr.Write([]byte{'\n'})
+			debugOutput.Write(redactDump(dump))
+			debugOutput.Write([]byte{'\n'})
 		}
 	}
 
 	if err = checkResponse(resp); err != nil {
+		resp.Body.Close()
 		return nil, err
 	}
 
-	return resp, nil
-}
-
-type Error struct {
-	error
-	ID  string
-	URL string
-}
-
-func checkResponse(resp *http.Response) error {
-	if resp.StatusCode/100 != 2 { // 200, 201, 202, etc
-		var e struct {
-			Message string
-			ID      string
-			URL     string `json:"url"`
-		}
-		err := json.NewDecoder(resp.Body).Decode(&e)
-		if err != nil {
-			return fmt.Errorf("encountered an error : %s", resp.Status)
-		}
-		return Error{error: errors.New(e.Message), ID: e.ID, URL: e.URL}
-	}
 	if msg := resp.Header.Get("X-Heroku-Warning"); msg != "" {
-		log.Println(os.Stderr, strings.TrimSpace(msg))
+		log.Println(strings.TrimSpace(msg))
 	}
-	return nil
+
+	return resp, nil
 }
 
 // cloneRequest returns a clone of the provided *http.Request.
@@ -0,0 +1,62 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// IsTwoFactorRequired reports whether err is caused by an operation that
+// requires a second factor, such as deleting a protected app. The
+// operation can be retried with a code from the user's authenticator app
+// using WithTwoFactorCode.
+func IsTwoFactorRequired(err error) bool {
+	e, ok := asError(err)
+	return ok && e.ID == "two_factor"
+}
+
+type twoFactorCodeContextKey struct{}
+
+// WithTwoFactorCode returns a copy of ctx that makes requests sent with
+// it, e.g. using DoWithContext, carry code in their Heroku-Two-Factor-Code
+// header:
+//
+//	err := s.DoWithContext(ctx, nil, "DELETE", "/apps/example", nil, nil)
+//	if heroku.IsTwoFactorRequired(err) {
+//		ctx = heroku.WithTwoFactorCode(ctx, promptCode())
+//		err = s.DoWithContext(ctx, nil, "DELETE", "/apps/example", nil, nil)
+//	}
+func WithTwoFactorCode(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, twoFactorCodeContextKey{}, code)
+}
+
+// setTwoFactorCode sets the Heroku-Two-Factor-Code header of req from
+// its context, if any.
+func setTwoFactorCode(req *http.Request) {
+	if code, ok := req.Context().Value(twoFactorCodeContextKey{}).(string); ok && code != "" {
+		req.Header.Set("Heroku-Two-Factor-Code", code)
+	}
+}
+
+// AccountRecoveryCodesCreate generates new recovery codes for the
+// account, which must have two-factor authentication enabled, and returns
+// them. The previous recovery codes stop working. code is a code from the
+// user's authenticator app.
+func (s *Service) AccountRecoveryCodesCreate(code string) ([]string, error) {
+	var codes []string
+	return codes, s.DoWithContext(WithTwoFactorCode(context.Background(), code), &codes, "POST", "/account/recovery-codes", nil, nil)
+}
+
+// AppDeleteWithTwoFactor is like AppDelete but sends code, a code from
+// the user's authenticator app, as second factor for apps that require
+// one to be deleted.
+func (s *Service) AppDeleteWithTwoFactor(appIdentity, code string) error {
+	return s.DoWithContext(WithTwoFactorCode(context.Background(), code), nil, "DELETE", fmt.Sprintf("/apps/%v", pathEscape(appIdentity)), nil, nil)
+}
+
+// SSLEndpointDeleteWithTwoFactor is like SSLEndpointDelete but sends
+// code, a code from the user's authenticator app, as second factor for
+// apps that require one.
+func (s *Service) SSLEndpointDeleteWithTwoFactor(appIdentity, sslEndpointIdentity, code string) error {
+	return s.DoWithContext(WithTwoFactorCode(context.Background(), code), nil, "DELETE", fmt.Sprintf("/apps/%v/ssl-endpoints/%v", pathEscape(appIdentity), pathEscape(sslEndpointIdentity)), nil, nil)
+}
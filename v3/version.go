@@ -0,0 +1,41 @@
+package heroku
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// APIVersion returns the API version a response was served in, such as
+// "3", as given by the version parameter of its Content-Type, e.g.
+// application/vnd.heroku+json; version=3, or by its X-Heroku-API-Version
+// header. It returns "" if the response does not say.
+//
+// Responses returned by DoResponse can be checked to make sure that the
+// API serves the version this package was written for:
+//
+//	resp, err := s.DoResponse(&app, "GET", "/apps/example", nil, nil)
+//	if err == nil && heroku.APIVersion(resp) != "3" {
+//		...
+//	}
+func APIVersion(resp *http.Response) string {
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+		if v := params["version"]; v != "" {
+			return v
+		}
+	}
+	return strings.TrimPrefix(resp.Header.Get("X-Heroku-API-Version"), "v")
+}
+
+// checkAPIVersion logs a warning to the logger of s if resp was served in
+// an API version other than Version.
+func (s *Service) checkAPIVersion(req *http.Request, resp *http.Response) {
+	if s.Logger == nil {
+		return
+	}
+	// Variants, such as 3.add-ons-sso, belong to their major version.
+	v := APIVersion(resp)
+	if major, _, _ := strings.Cut(v, "."); v != "" && "v"+major != Version {
+		s.Logger.Printf("heroku: %s %s served API version %s, expected %s", req.Method, req.URL.Path, v, strings.TrimPrefix(Version, "v"))
+	}
+}
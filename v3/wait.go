@@ -0,0 +1,52 @@
+package heroku
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TimeoutError is returned by methods that wait for a resource when it
+// did not reach the expected state in time.
+type TimeoutError struct {
+	Op      string        // what was waited for, e.g. "dyno web.1 to be up"
+	Timeout time.Duration // how long it was waited
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("heroku: timed out after %v waiting for %s", e.Timeout, e.Op)
+}
+
+// IsTimeout reports whether err is caused by waiting for a resource for
+// longer than allowed.
+func IsTimeout(err error) bool {
+	_, ok := err.(*TimeoutError)
+	return ok
+}
+
+// poll calls check with DefaultBackoff between attempts until it reports
+// done or fails, or until timeout has elapsed. It returns the error of
+// ctx as soon as ctx is canceled.
+func poll(ctx context.Context, op string, timeout time.Duration, check func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	for attempt := 1; ; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+		d := DefaultBackoff.Next(attempt)
+		if time.Now().Add(d).After(deadline) {
+			return &TimeoutError{Op: op, Timeout: timeout}
+		}
+		timer := time.NewTimer(d)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
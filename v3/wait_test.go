@@ -0,0 +1,77 @@
+package heroku
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPollCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	err := poll(ctx, "nothing", time.Hour, func() (bool, error) { return false, nil })
+	if err != context.Canceled {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("poll returned after %v, want soon after cancellation", d)
+	}
+}
+
+func TestPollTimeout(t *testing.T) {
+	err := poll(context.Background(), "nothing", time.Millisecond, func() (bool, error) { return false, nil })
+	if !IsTimeout(err) {
+		t.Errorf("err = %v, want a TimeoutError", err)
+	}
+}
+
+// hangingService returns a Service whose API requests hang until they are
+// canceled.
+func hangingService(t *testing.T) *Service {
+	return newTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+}
+
+func TestWaitCanceledDuringRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		wait func(context.Context, *Service) error
+	}{
+		{"DynoRestartAndWait", func(ctx context.Context, s *Service) error {
+			_, err := s.DynoRestartAndWaitWithContext(ctx, "example", "web.1", time.Hour)
+			return err
+		}},
+		{"AddonUpdateAndWait", func(ctx context.Context, s *Service) error {
+			_, _, err := s.AddonUpdateAndWaitWithContext(ctx, "example", "heroku-postgresql", AddonUpdateOpts{Plan: "standard-0"}, time.Hour)
+			return err
+		}},
+		{"FormationScaleAndWait", func(ctx context.Context, s *Service) error {
+			_, err := s.FormationScaleAndWaitWithContext(ctx, "example", map[string]int{"web": 2}, time.Hour)
+			return err
+		}},
+		{"Deploy", func(ctx context.Context, s *Service) error {
+			_, err := s.DeployWithContext(ctx, "example", t.TempDir(), nil)
+			return err
+		}},
+		{"SlugPromote", func(ctx context.Context, s *Service) error {
+			_, err := s.SlugPromoteWithContext(ctx, "staging", "01", "production")
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		start := time.Now()
+		if err := tt.wait(ctx, hangingService(t)); err == nil {
+			t.Errorf("%s: err = nil after cancellation", tt.name)
+		}
+		if d := time.Since(start); d > 5*time.Second {
+			t.Errorf("%s returned after %v, want soon after cancellation", tt.name, d)
+		}
+	}
+}